@@ -6,6 +6,7 @@ import (
 	"github.com/VinVorteX/NoBurn/internal/database"
 	"github.com/VinVorteX/NoBurn/internal/config"
 	"github.com/VinVorteX/NoBurn/internal/server"
+	"github.com/VinVorteX/NoBurn/internal/services"
 	"github.com/VinVorteX/NoBurn/pkg/logger"
 )
 
@@ -20,6 +21,11 @@ func main() {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
+	// Surveys are scored inline, so a bad SENTIMENT_PROVIDERS should stop startup
+	if _, err := services.NewSentimentService(config.AppConfig); err != nil {
+		log.Fatalf("Invalid sentiment providers: %v", err)
+	}
+
 	router := server.New()
 
 	log.Printf("✅ NoBurn HR Analytics server starting in %s mode on port %s", config.AppConfig.Env, config.AppConfig.Port)
@@ -31,12 +31,25 @@ func main() {
 	surveyRepo := repository.NewSurveyRepository()
 
 	// Initialize services
-	analyticsService := services.NewAnalyticsService(userRepo, surveyRepo, "http://localhost:5000")
+	mlService, err := services.SharedSentimentService()
+	if err != nil {
+		log.Fatalf("Invalid sentiment providers: %v", err)
+	}
+	analyticsService := services.NewAnalyticsService(userRepo, surveyRepo, mlService)
 
 	// Initialize worker server
 	// Parse Redis URL to get host:port (Asynq expects "host:port" not "redis://host:port")
 	redisAddr := strings.TrimPrefix(config.AppConfig.RedisURL, "redis://")
-	workerServer := worker.NewWorkerServer(redisAddr, analyticsService, userRepo, surveyRepo)
+	workerServer := worker.NewWorkerServer(redisAddr, analyticsService, services.NewSurveyService(mlService), userRepo, surveyRepo)
+
+	// Initialize scheduler for recurring pulse surveys
+	surveyScheduler, err := worker.NewSurveyScheduler(redisAddr, repository.NewSurveyScheduleRepository(), repository.NewCompanyRepository())
+	if err != nil {
+		log.Fatalf("Failed to create survey scheduler: %v", err)
+	}
+	if err := surveyScheduler.Start(); err != nil {
+		log.Fatalf("Failed to start survey scheduler: %v", err)
+	}
 
 	// Handle graceful shutdown
 	c := make(chan os.Signal, 1)
@@ -45,6 +58,7 @@ func main() {
 	go func() {
 		<-c
 		log.Println("Shutting down worker server...")
+		surveyScheduler.Stop()
 		workerServer.Stop()
 		os.Exit(0)
 	}()
@@ -6,7 +6,7 @@ import { Button } from '@/components/ui/button';
 import { LoadingSpinner } from '@/components/ui/loading-spinner';
 import { EmptyState } from '@/components/ui/empty-state';
 import { analyticsAPI } from '@/services/api';
-import type { DashboardData } from '@/types';
+import type { AttritionRisk, DashboardData } from '@/types';
 import { toast } from 'sonner';
 import { useNavigate } from 'react-router-dom';
 import { Badge } from '@/components/ui/badge';
@@ -51,6 +51,13 @@ export const Dashboard = () => {
     return 'Low Risk';
   };
 
+  const getTopDriver = (risk: AttritionRisk) => {
+    if (!risk.contributions?.length) return null;
+    const top = risk.contributions.reduce((a, b) => (b.share > a.share ? b : a));
+    if (top.share <= 0) return null;
+    return `${top.label} contributed ${(top.share * 100).toFixed(0)}% of this score`;
+  };
+
   return (
     <DashboardLayout>
       <div className="space-y-8">
@@ -150,7 +157,12 @@ export const Dashboard = () => {
                     <TableBody>
                       {data.attrition_risks.slice(0, 5).map((risk) => (
                         <TableRow key={risk.id}>
-                          <TableCell className="font-medium">{risk.user.name}</TableCell>
+                          <TableCell className="font-medium">
+                            {risk.user.name}
+                            {getTopDriver(risk) && (
+                              <p className="text-xs font-normal text-muted-foreground">{getTopDriver(risk)}</p>
+                            )}
+                          </TableCell>
                           <TableCell className="text-muted-foreground">{risk.user.email}</TableCell>
                           <TableCell className="text-right font-semibold">
                             {(risk.risk_score * 100).toFixed(0)}%
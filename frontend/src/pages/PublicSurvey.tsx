@@ -23,14 +23,14 @@ export const PublicSurvey = () => {
 
   useEffect(() => {
     const fetchSurvey = async () => {
-      if (!surveyId) {
+      if (!surveyId || !userToken) {
         setError('Invalid survey link');
         setIsLoading(false);
         return;
       }
 
       try {
-        const response = await surveyAPI.getPublicSurvey(parseInt(surveyId));
+        const response = await surveyAPI.getPublicSurvey(parseInt(surveyId), userToken);
         setSurvey(response);
         setResponses(new Array(response.questions.length).fill(''));
       } catch (error: any) {
@@ -41,7 +41,7 @@ export const PublicSurvey = () => {
     };
 
     fetchSurvey();
-  }, [surveyId]);
+  }, [surveyId, userToken]);
 
   const handleResponseChange = (index: number, value: string) => {
     setResponses((prev) => prev.map((r, i) => (i === index ? value : r)));
@@ -65,7 +65,7 @@ export const PublicSurvey = () => {
     try {
       await surveyAPI.submitPublicResponse({
         survey_id: parseInt(surveyId),
-        user_token: parseInt(userToken),
+        user_token: userToken,
         responses,
       });
       setIsSubmitted(true);
@@ -158,7 +158,7 @@ export const PublicSurvey = () => {
               >
                 <Label className="text-base font-medium text-foreground mb-3 block">
                   <span className="text-primary mr-2">{index + 1}.</span>
-                  {question}
+                  {question.text}
                 </Label>
                 <Textarea
                   placeholder="Type your answer here..."
@@ -5,6 +5,7 @@ import { FileText, TrendingUp, TrendingDown, Minus, Calendar, User } from 'lucid
 import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
 import axios from 'axios';
 import { toast } from 'sonner';
+import type { SurveyQuestion } from '@/types';
 
 interface SurveyResponse {
   id: number;
@@ -21,7 +22,7 @@ interface SurveyResponse {
 interface Survey {
   id: number;
   title: string;
-  questions: string[];
+  questions: SurveyQuestion[];
   created_at: string;
 }
 
@@ -192,7 +193,7 @@ export const SurveyResults = () => {
                       {survey.questions.map((question, qIdx) => (
                         <div key={qIdx} className="space-y-1">
                           <p className="text-sm font-medium text-muted-foreground">
-                            Q{qIdx + 1}: {question}
+                            Q{qIdx + 1}: {question.text}
                           </p>
                           <p className="text-sm pl-4 border-l-2 border-primary/20 py-1">
                             {response.responses[qIdx] || 'No answer'}
@@ -69,8 +69,8 @@ export const surveyAPI = {
     return response.data.data;
   },
   
-  getPublicSurvey: async (surveyId: number): Promise<PublicSurvey> => {
-    const response = await api.get(`/api/surveys/${surveyId}/public`);
+  getPublicSurvey: async (surveyId: number, token: string): Promise<PublicSurvey> => {
+    const response = await api.get(`/api/surveys/${surveyId}/public`, { params: { token } });
     return response.data.data;
   },
   
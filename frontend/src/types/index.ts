@@ -2,15 +2,27 @@ export interface User {
   id: number;
   email: string;
   name: string;
-  role: string;
+  role: 'hr_admin' | 'people_manager' | 'employee' | 'executive';
   company_id: number;
+  department_id?: number;
+  team_id?: number;
+  manager_id?: number;
+}
+
+export type QuestionType = 'text' | 'likert' | 'nps' | 'single_choice' | 'multi_choice';
+
+export interface SurveyQuestion {
+  text: string;
+  type: QuestionType;
+  options?: string[];
+  required: boolean;
 }
 
 export interface Survey {
   id: number;
   company_id: number;
   title: string;
-  questions: string[];
+  questions: SurveyQuestion[];
   is_active: boolean;
   created_at: string;
 }
@@ -20,14 +32,24 @@ export interface DashboardData {
   at_risk_employees: number;
   avg_sentiment: number;
   churn_rate: number;
+  attrition_rate: number;
   top_risk_factors: string[];
   attrition_risks: AttritionRisk[];
 }
 
+export interface FeatureContribution {
+  feature: string;
+  label: string;
+  value: number;
+  impact: number;
+  share: number;
+}
+
 export interface AttritionRisk {
   id: number;
   user_id: number;
   risk_score: number;
+  contributions: FeatureContribution[] | null;
   user: User;
 }
 
@@ -39,7 +61,7 @@ export interface AuthResponse {
 
 export interface SurveyResponse {
   survey_id: number;
-  user_token: number;
+  user_token: string;
   responses: string[];
 }
 
@@ -47,5 +69,5 @@ export interface PublicSurvey {
   id: number;
   title: string;
   description?: string;
-  questions: string[];
+  questions: SurveyQuestion[];
 }
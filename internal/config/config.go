@@ -15,6 +15,18 @@ type Config struct {
 	JwtSecret string `mapstructure:"JWT_SECRET"`
 	JwtExpiresIn time.Duration `mapstructure:"JWT_EXPIRES_IN"`
 	HuggingFaceToken string `mapstructure:"HUGGING_FACE_TOKEN"`
+	HuggingFaceURL string `mapstructure:"HUGGING_FACE_URL"`
+	HuggingFaceModels string `mapstructure:"HUGGING_FACE_MODELS"` // "default-model;en=english-model"
+	MLAPIURL string `mapstructure:"ML_API_URL"`
+	MLTimeout time.Duration `mapstructure:"ML_TIMEOUT"`
+	MLMaxRetries int `mapstructure:"ML_MAX_RETRIES"`
+	MLRetryBackoff time.Duration `mapstructure:"ML_RETRY_BACKOFF"`
+	MLMaxRetryWait time.Duration `mapstructure:"ML_MAX_RETRY_WAIT"`
+	MLBreakerThreshold int `mapstructure:"ML_BREAKER_THRESHOLD"` // 0 disables the breaker
+	MLBreakerCooldown time.Duration `mapstructure:"ML_BREAKER_COOLDOWN"`
+	MLCompanyRateLimit float64 `mapstructure:"ML_COMPANY_RATE_LIMIT"` // calls per minute, 0 for no limit
+	MLCompanyBurst int `mapstructure:"ML_COMPANY_BURST"`
+	SentimentProviders string `mapstructure:"SENTIMENT_PROVIDERS"`
 	RedisURL string `mapstructure:"REDIS_URL"`
 	SlackWebhookURL string `mapstructure:"SLACK_WEBHOOK_URL"`
 	SMTPHost string `mapstructure:"SMTP_HOST"`
@@ -22,6 +34,9 @@ type Config struct {
 	SMTPUser string `mapstructure:"SMTP_USER"`
 	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
 	AlertEmail string `mapstructure:"ALERT_EMAIL"`
+	SurveyTokenTTL time.Duration `mapstructure:"SURVEY_TOKEN_TTL"`
+	AnonymitySecret string `mapstructure:"ANONYMITY_SECRET"`
+	AnonymityMinGroupSize int `mapstructure:"ANONYMITY_MIN_GROUP_SIZE"`
 }
 
 var AppConfig *Config
@@ -31,6 +46,18 @@ func LoadConfig() error{
 	viper.SetDefault("PORT", "8080")
 	viper.SetDefault("ENV", "development")
 	viper.SetDefault("JWT_EXPIRES_IN", "24h")
+	viper.SetDefault("SURVEY_TOKEN_TTL", "168h")
+	viper.SetDefault("ANONYMITY_MIN_GROUP_SIZE", 5)
+	viper.SetDefault("SENTIMENT_PROVIDERS", "huggingface,lexicon")
+	viper.SetDefault("HUGGING_FACE_URL", "https://api-inference.huggingface.co/models")
+	viper.SetDefault("ML_TIMEOUT", "10s")
+	viper.SetDefault("ML_MAX_RETRIES", 2)
+	viper.SetDefault("ML_RETRY_BACKOFF", "500ms")
+	viper.SetDefault("ML_MAX_RETRY_WAIT", "5s")
+	viper.SetDefault("ML_BREAKER_THRESHOLD", 5)
+	viper.SetDefault("ML_BREAKER_COOLDOWN", "30s")
+	viper.SetDefault("ML_COMPANY_RATE_LIMIT", 60)
+	viper.SetDefault("ML_COMPANY_BURST", 10)
 
 	viper.SetConfigName(".env") // name of config file
 	viper.SetConfigType("env") // type of config file
@@ -44,6 +71,18 @@ func LoadConfig() error{
 	viper.BindEnv("JWT_SECRET")
 	viper.BindEnv("JWT_EXPIRES_IN")
 	viper.BindEnv("HUGGING_FACE_TOKEN")
+	viper.BindEnv("HUGGING_FACE_URL")
+	viper.BindEnv("HUGGING_FACE_MODELS")
+	viper.BindEnv("ML_API_URL")
+	viper.BindEnv("ML_TIMEOUT")
+	viper.BindEnv("ML_MAX_RETRIES")
+	viper.BindEnv("ML_RETRY_BACKOFF")
+	viper.BindEnv("ML_MAX_RETRY_WAIT")
+	viper.BindEnv("ML_BREAKER_THRESHOLD")
+	viper.BindEnv("ML_BREAKER_COOLDOWN")
+	viper.BindEnv("ML_COMPANY_RATE_LIMIT")
+	viper.BindEnv("ML_COMPANY_BURST")
+	viper.BindEnv("SENTIMENT_PROVIDERS")
 	viper.BindEnv("REDIS_URL")
 	viper.BindEnv("SLACK_WEBHOOK_URL")
 	viper.BindEnv("SMTP_HOST")
@@ -51,6 +90,9 @@ func LoadConfig() error{
 	viper.BindEnv("SMTP_USER")
 	viper.BindEnv("SMTP_PASSWORD")
 	viper.BindEnv("ALERT_EMAIL")
+	viper.BindEnv("SURVEY_TOKEN_TTL")
+	viper.BindEnv("ANONYMITY_SECRET")
+	viper.BindEnv("ANONYMITY_MIN_GROUP_SIZE")
 
 	viper.AutomaticEnv()
 
@@ -76,6 +118,11 @@ func LoadConfig() error{
 		return fmt.Errorf("JWT_SECRET field is required")
 	}
 
+	if AppConfig.AnonymitySecret == ""{
+		// Participation markers still can't be reversed from the database without this
+		AppConfig.AnonymitySecret = AppConfig.JwtSecret
+	}
+
 	if AppConfig.Env == "development"{
 		viper.Debug()
 	}
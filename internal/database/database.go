@@ -1,6 +1,9 @@
 package database
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/VinVorteX/NoBurn/internal/config"
 	"github.com/VinVorteX/NoBurn/internal/models"
 	"github.com/VinVorteX/NoBurn/pkg/logger"
@@ -10,6 +13,15 @@ import (
 
 var DB *gorm.DB
 
+// TenantRole is the role tenant transactions switch to. Migration 000025 creates it
+// without BYPASSRLS, so the row-level security policies apply to it, while the
+// connecting owner role that background jobs use is not subject to them.
+const TenantRole = "noburn_tenant"
+
+// tenantRole is set once the connection is known to be able to switch to TenantRole.
+// Only development may run without it, since dev-mode AutoMigrate does not create it.
+var tenantRole bool
+
 func Connect() error {
 	var err error
 
@@ -34,10 +46,23 @@ func Connect() error {
 	if config.AppConfig.Env == "development" {
 		if err := DB.AutoMigrate(
 			&models.Company{},
+			&models.Department{},
+			&models.Team{},
 			&models.User{},
 			&models.Survey{},
 			&models.SurveyResponse{},
 			&models.AttritionRisk{},
+			&models.SurveyToken{},
+			&models.SurveySchedule{},
+			&models.SurveyParticipation{},
+			&models.UserActivity{},
+			&models.ChurnModel{},
+			&models.EmployeeExit{},
+			&models.AlertRule{},
+			&models.Alert{},
+			&models.RiskSettings{},
+			&models.AnswerSentiment{},
+			&models.ResponseAspect{},
 		); err != nil {
 			logger.Log.Warn("AutoMigrate failed, use migrations instead")
 		}
@@ -45,6 +70,13 @@ func Connect() error {
 	}
 	// Production: Use 'make migrate-up' instead
 
+	if err := checkTenantRole(); err != nil {
+		if config.AppConfig.Env != "development" {
+			return fmt.Errorf("row-level security unavailable: %w", err)
+		}
+		logger.Log.Warn("Row-level security is not enforced in development: " + err.Error())
+	}
+
 	logger.Log.Info("Connected to database and migrated models")
 	return nil
 }
@@ -58,4 +90,46 @@ func Close() error {
 		return sqlDB.Close()
 	}
 	return nil
-}
\ No newline at end of file
+}
+// checkTenantRole makes sure tenant transactions can switch to TenantRole, and enables
+// the switch if so
+func checkTenantRole() error {
+	tenantRole = false
+
+	var exists bool
+	if err := DB.Raw("SELECT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = ?)", TenantRole).Scan(&exists).Error; err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("role %s does not exist; run 'make migrate-up'", TenantRole)
+	}
+
+	if err := DB.Transaction(func(tx *gorm.DB) error {
+		return tx.Exec("SET LOCAL ROLE " + TenantRole).Error
+	}); err != nil {
+		return fmt.Errorf("cannot switch to role %s: %w", TenantRole, err)
+	}
+
+	tenantRole = true
+	return nil
+}
+
+// WithCompany runs fn in a transaction as TenantRole with the app.company_id setting
+// applied, so the row-level security policies only expose that company's rows even if
+// a query forgets to filter on it. The role and setting are local to the transaction
+// and cleared when the connection returns to the pool.
+func WithCompany(companyID uint, fn func(tx *gorm.DB) error) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			if tenantRole {
+				if err := tx.Exec("SET LOCAL ROLE " + TenantRole).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Exec("SELECT set_config('app.company_id', ?, true)", strconv.FormatUint(uint64(companyID), 10)).Error; err != nil {
+				return err
+			}
+		}
+		return fn(tx)
+	})
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupPostgres applies the migrations to the database in TEST_DB_URL, wiping its
+// public schema first, and seeds two companies with one employee and survey each.
+// Tests using it are skipped when TEST_DB_URL is not set.
+func setupPostgres(t *testing.T) (companyA, companyB uint) {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DB_URL")
+	if dsn == "" {
+		t.Skip("TEST_DB_URL not set; skipping Postgres row-level security tests")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	previous := DB
+	DB = db
+	t.Cleanup(func() {
+		DB = previous
+		tenantRole = false
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	for _, stmt := range []string{"DROP SCHEMA IF EXISTS public CASCADE", "CREATE SCHEMA public"} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("Failed to reset schema: %v", err)
+		}
+	}
+
+	files, err := filepath.Glob("../../migrations/*.up.sql")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("Failed to find migrations: %v", err)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		sql, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file, err)
+		}
+		if err := db.Exec(string(sql)).Error; err != nil {
+			t.Fatalf("Failed to apply %s: %v", filepath.Base(file), err)
+		}
+	}
+
+	if err := checkTenantRole(); err != nil {
+		t.Fatalf("Expected migrations to set up the %s role: %v", TenantRole, err)
+	}
+
+	// Seed as the owner, which row-level security does not apply to
+	seed := func(name string) uint {
+		var companyID uint
+		if err := db.Raw("INSERT INTO companies (name) VALUES (?) RETURNING id", name).Scan(&companyID).Error; err != nil {
+			t.Fatalf("Failed to create company: %v", err)
+		}
+		if err := db.Exec("INSERT INTO users (email, password, name, company_id) VALUES (?, 'x', ?, ?)", name+"@example.com", name, companyID).Error; err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		if err := db.Exec("INSERT INTO surveys (company_id, title) VALUES (?, ?)", companyID, name+" pulse").Error; err != nil {
+			t.Fatalf("Failed to create survey: %v", err)
+		}
+		return companyID
+	}
+	return seed("acme"), seed("globex")
+}
+
+func TestTenantCannotReadAnotherCompany(t *testing.T) {
+	companyA, companyB := setupPostgres(t)
+
+	// None of these queries filter on the company, so only the policies hide B's rows
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"companies", "SELECT COUNT(*) FROM companies WHERE id = ?"},
+		{"users", "SELECT COUNT(*) FROM users WHERE company_id = ?"},
+		{"surveys", "SELECT COUNT(*) FROM surveys WHERE company_id = ?"},
+	}
+
+	for _, tt := range tests {
+		var own, other int64
+		err := WithCompany(companyA, func(tx *gorm.DB) error {
+			if err := tx.Raw(tt.query, companyA).Scan(&own).Error; err != nil {
+				return err
+			}
+			return tx.Raw(tt.query, companyB).Scan(&other).Error
+		})
+		if err != nil {
+			t.Fatalf("Failed to query %s: %v", tt.name, err)
+		}
+		if own != 1 {
+			t.Errorf("Expected the tenant to see its own %s, got %d rows", tt.name, own)
+		}
+		if other != 0 {
+			t.Errorf("Expected no %s from another company, got %d rows", tt.name, other)
+		}
+	}
+}
+
+func TestTenantCannotWriteAnotherCompany(t *testing.T) {
+	companyA, companyB := setupPostgres(t)
+
+	err := WithCompany(companyA, func(tx *gorm.DB) error {
+		return tx.Exec("INSERT INTO surveys (company_id, title) VALUES (?, 'planted')", companyB).Error
+	})
+	if err == nil {
+		t.Error("Expected inserting a survey for another company to be rejected")
+	}
+}
+
+func TestMissingCompanyFailsClosed(t *testing.T) {
+	setupPostgres(t)
+
+	var count int64
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL ROLE " + TenantRole).Error; err != nil {
+			return err
+		}
+		return tx.Raw("SELECT COUNT(*) FROM users").Scan(&count).Error
+	})
+	if err != nil {
+		t.Fatalf("Failed to query users: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected no users without app.company_id, got %d", count)
+	}
+}
+
+func TestOwnerConnectionSeesAllCompanies(t *testing.T) {
+	setupPostgres(t)
+
+	var count int64
+	if err := DB.Raw("SELECT COUNT(*) FROM users").Scan(&count).Error; err != nil {
+		t.Fatalf("Failed to query users: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected background jobs to see both companies' users, got %d", count)
+	}
+}
@@ -2,9 +2,13 @@ package analytics
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/VinVorteX/NoBurn/internal/models"
+	middlewareAuth "github.com/VinVorteX/NoBurn/internal/middleware/auth"
+	"github.com/VinVorteX/NoBurn/internal/policy"
 	"github.com/VinVorteX/NoBurn/internal/repository"
 	"github.com/VinVorteX/NoBurn/internal/services"
 	"github.com/VinVorteX/NoBurn/internal/utils"
@@ -14,36 +18,52 @@ type DashboardData struct {
 	TotalEmployees   int                    `json:"total_employees"`
 	AtRiskEmployees  int                    `json:"at_risk_employees"`
 	AvgSentiment     float64                `json:"avg_sentiment"`
-	ChurnRate        float64                `json:"churn_rate"`
-	TopRiskFactors   []string               `json:"top_risk_factors"`
+	ChurnRate        float64                `json:"churn_rate"`     // predicted: percent of employees at high risk
+	AttritionRate    float64                `json:"attrition_rate"` // actual: percent who left in the past year
+	Attrition        services.AttritionStats `json:"attrition"`
+	TopRiskFactors   []string               `json:"top_risk_factors"` // aspects with the most negative mentions
+	Aspects          []services.AspectSummary `json:"aspects"`
 	AttritionRisks   []models.AttritionRisk `json:"attrition_risks"`
+	Departments      []services.GroupRisk   `json:"departments"`
+	Teams            []services.GroupRisk   `json:"teams"`
 }
 
 func GetDashboard(w http.ResponseWriter, r *http.Request) {
-	userID := r.Context().Value("userID").(uint)
-	userRepo := repository.NewUserRepository()
-	user, err := userRepo.GetByID(userID)
+	user := middlewareAuth.CurrentUser(r)
+	userRepo := repository.NewUserRepository().ForTenant(r.Context())
+
+	scope, err := policy.ScopeFor(r.Context(), user)
 	if err != nil {
-		utils.WriteError(w, http.StatusUnauthorized, "User not found")
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to resolve visibility")
 		return
 	}
 
-	// Get all company employees
+	// Get the employees this user may see
 	employees, _ := userRepo.GetByCompanyID(user.CompanyID)
+	employees = scope.Users(employees)
 	totalEmployees := len(employees)
 
+	riskSettings, err := repository.NewRiskSettingsRepository().ForTenant(r.Context()).GetCurrent(user.CompanyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to load risk settings")
+		return
+	}
+
 	// Get high-risk employees
-	attritionRepo := repository.NewAttritionRepository()
-	atRiskUsers, _ := attritionRepo.GetHighRiskUsers(user.CompanyID, 0.7)
+	attritionRepo := repository.NewAttritionRepository().ForTenant(r.Context())
+	atRiskUsers, _ := attritionRepo.GetCurrentRisks(user.CompanyID, riskSettings.HighRiskThreshold)
+	atRiskUsers = scope.Risks(atRiskUsers)
 	atRiskCount := len(atRiskUsers)
 
 	// Calculate average sentiment
-	surveyRepo := repository.NewSurveyRepository()
+	surveyRepo := repository.NewSurveyRepository().ForTenant(r.Context())
 	avgSentiment := 0.0
 	totalResponses := 0
+	visible := map[uint]bool{}
 	for _, emp := range employees {
+		visible[emp.ID] = true
 		responses, _ := surveyRepo.GetResponsesByUserID(emp.ID)
-		for _, resp := range responses {
+		for _, resp := range models.ScoredResponses(responses) {
 			avgSentiment += resp.Sentiment
 			totalResponses++
 		}
@@ -52,62 +72,192 @@ func GetDashboard(w http.ResponseWriter, r *http.Request) {
 		avgSentiment /= float64(totalResponses)
 	}
 
+	// Risk factors come from what the company's responses say about each aspect,
+	// anonymous surveys included once enough people have answered them
+	scored, _ := surveyRepo.GetScoredResponsesByCompanyID(user.CompanyID)
+	aspectSummaries := services.DashboardAspects(scored, func(userID uint) bool { return visible[userID] }, scope.All(), services.MinGroupSize())
+
 	churnRate := 0.0
 	if totalEmployees > 0 {
 		churnRate = (float64(atRiskCount) / float64(totalEmployees)) * 100
 	}
 
+	// Actual attrition over the past year, next to the predicted churn rate
+	exits, _ := repository.NewExitRepository().ForTenant(r.Context()).GetByCompanyID(user.CompanyID, time.Now().Add(-services.AttritionWindow))
+	attrition := services.CalculateAttrition(scope.Exits(exits), totalEmployees, time.Now().Add(-services.AttritionWindow))
+
 	dashboard := DashboardData{
 		TotalEmployees:  totalEmployees,
 		AtRiskEmployees: atRiskCount,
 		AvgSentiment:    avgSentiment,
 		ChurnRate:       churnRate,
-		TopRiskFactors:  []string{"Work-life balance", "Career growth", "Compensation"},
+		AttritionRate:   attrition.Rate,
+		Attrition:       attrition,
+		TopRiskFactors:  services.TopRiskFactors(aspectSummaries, 3),
+		Aspects:         aspectSummaries,
 		AttritionRisks:  atRiskUsers,
+		Departments:     services.RiskByDepartment(employees, atRiskUsers),
+		Teams:           services.RiskByTeam(employees, atRiskUsers),
 	}
 
 	utils.WriteSuccess(w, dashboard)
 }
 
 func GetAttritionRisks(w http.ResponseWriter, r *http.Request) {
-	userID := r.Context().Value("userID").(uint)
-	userRepo := repository.NewUserRepository()
-	user, err := userRepo.GetByID(userID)
+	user := middlewareAuth.CurrentUser(r)
+
+	scope, err := policy.ScopeFor(r.Context(), user)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to resolve visibility")
+		return
+	}
+
+	riskSettings, err := repository.NewRiskSettingsRepository().ForTenant(r.Context()).GetCurrent(user.CompanyID)
 	if err != nil {
-		utils.WriteError(w, http.StatusUnauthorized, "User not found")
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to load risk settings")
 		return
 	}
 
-	attritionRepo := repository.NewAttritionRepository()
-	risks, err := attritionRepo.GetHighRiskUsers(user.CompanyID, 0.5)
+	attritionRepo := repository.NewAttritionRepository().ForTenant(r.Context())
+	risks, err := attritionRepo.GetCurrentRisks(user.CompanyID, riskSettings.WatchThreshold)
 	if err != nil {
 		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch risks")
 		return
 	}
 
-	utils.WriteSuccess(w, risks)
+	utils.WriteSuccess(w, scope.Risks(risks))
+}
+
+type RiskHistory struct {
+	UserID  uint                   `json:"user_id"`
+	Current *models.AttritionRisk  `json:"current"` // latest calculation, even if older than the window
+	History []models.AttritionRisk `json:"history"`
+	Weekly  []services.RiskWeek    `json:"weekly"`
+}
+
+// GetRiskHistory returns an employee's risk calculations and week-over-week trend.
+// ?weeks= sets how far back to look (default 12, at most 52).
+func GetRiskHistory(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseUint(chi.URLParam(r, "userID"), 10, 32)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	weeks := 12
+	if raw := r.URL.Query().Get("weeks"); raw != "" {
+		weeks, err = strconv.Atoi(raw)
+		if err != nil || weeks < 1 || weeks > 52 {
+			utils.WriteError(w, http.StatusBadRequest, "weeks must be between 1 and 52")
+			return
+		}
+	}
+
+	user := middlewareAuth.CurrentUser(r)
+	scope, err := policy.ScopeFor(r.Context(), user)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to resolve visibility")
+		return
+	}
+
+	employee, err := repository.NewUserRepository().ForTenant(r.Context()).GetByID(uint(userID))
+	if err != nil || employee.CompanyID != user.CompanyID || !scope.Includes(employee.ID) {
+		utils.WriteError(w, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	now := time.Now()
+	attritionRepo := repository.NewAttritionRepository().ForTenant(r.Context())
+	history, err := attritionRepo.GetHistory(employee.ID, now.AddDate(0, 0, -7*weeks))
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch risk history")
+		return
+	}
+
+	response := RiskHistory{
+		UserID:  employee.ID,
+		History: history,
+		Weekly:  services.WeeklyRiskTrend(history, now),
+	}
+	if current, err := attritionRepo.GetByUserID(employee.ID); err == nil {
+		response.Current = current
+	}
+
+	utils.WriteSuccess(w, response)
+}
+
+// GetAlerts lists the alerts raised in the past 30 days about employees the caller can see
+func GetAlerts(w http.ResponseWriter, r *http.Request) {
+	user := middlewareAuth.CurrentUser(r)
+
+	scope, err := policy.ScopeFor(r.Context(), user)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to resolve visibility")
+		return
+	}
+
+	alerts, err := repository.NewAlertRepository().ForTenant(r.Context()).GetByCompanyID(user.CompanyID, time.Now().AddDate(0, 0, -30))
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch alerts")
+		return
+	}
+
+	visible := []models.Alert{}
+	for _, alert := range alerts {
+		if scope.Includes(alert.UserID) {
+			visible = append(visible, alert)
+		}
+	}
+
+	utils.WriteSuccess(w, visible)
 }
 
 func GetRetentionSuggestions(w http.ResponseWriter, r *http.Request) {
-	userID := chi.URLParam(r, "userID")
+	userID, err := strconv.ParseUint(chi.URLParam(r, "userID"), 10, 32)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	user := middlewareAuth.CurrentUser(r)
+	scope, err := policy.ScopeFor(r.Context(), user)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to resolve visibility")
+		return
+	}
+
+	// Employees outside the caller's company or reporting line look like they don't exist
+	employee, err := repository.NewUserRepository().ForTenant(r.Context()).GetByID(uint(userID))
+	if err != nil || employee.CompanyID != user.CompanyID || !scope.Includes(employee.ID) {
+		utils.WriteError(w, http.StatusNotFound, "Employee not found")
+		return
+	}
+
 	language := r.URL.Query().Get("language")
 	if language == "" {
 		language = "en"
 	}
 	
-	// TODO: Convert userID string to uint and use actual service
-	// For now, return AI-generated suggestions
-	aiService := services.NewAIService("") // TODO: Get from config
-	riskFactors := []string{"Low sentiment scores", "Poor survey participation"}
-	
-	suggestions, err := aiService.GenerateRetentionSuggestions(riskFactors, language)
+	// Suggestions address the factors behind the employee's own churn features
+	mlService, err := services.SharedSentimentService()
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to generate suggestions")
+		return
+	}
+	analyticsService := services.NewAnalyticsService(
+		repository.NewUserRepository(),
+		repository.NewSurveyRepository(),
+		mlService,
+	).ForTenant(r.Context())
+
+	suggestions, err := analyticsService.GenerateRetentionSuggestions(employee.ID, language)
 	if err != nil {
 		utils.WriteError(w, http.StatusInternalServerError, "Failed to generate suggestions")
 		return
 	}
 
 	response := map[string]interface{}{
-		"user_id":     userID,
+		"user_id":     employee.ID,
 		"suggestions": suggestions,
 		"language":    language,
 	}
@@ -0,0 +1,45 @@
+package analytics
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	middlewareAuth "github.com/VinVorteX/NoBurn/internal/middleware/auth"
+	"github.com/VinVorteX/NoBurn/internal/repository"
+	"github.com/VinVorteX/NoBurn/internal/services"
+	"github.com/VinVorteX/NoBurn/internal/services/sentiment"
+	"github.com/VinVorteX/NoBurn/internal/utils"
+)
+
+// GetChurnModels lists the company's trained model versions, newest first
+func GetChurnModels(w http.ResponseWriter, r *http.Request) {
+	user := middlewareAuth.CurrentUser(r)
+
+	churnModels, err := repository.NewChurnModelRepository().ForTenant(r.Context()).GetByCompanyID(user.CompanyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch churn models")
+		return
+	}
+
+	utils.WriteSuccess(w, churnModels)
+}
+
+// TrainChurnModel retrains the company's churn model now instead of waiting for the weekly run
+func TrainChurnModel(w http.ResponseWriter, r *http.Request) {
+	user := middlewareAuth.CurrentUser(r)
+
+	model, err := services.NewChurnTrainingService().ForTenant(r.Context()).Train(user.CompanyID)
+	if errors.Is(err, sentiment.ErrInsufficientData) {
+		utils.WriteError(w, http.StatusUnprocessableEntity, "Not enough employees have left yet to train a model; default weights remain in use")
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Failed to train churn model for company %d: %v", user.CompanyID, err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to train churn model")
+		return
+	}
+
+	log.Printf("✅ Trained churn model v%d for company %d", model.Version, user.CompanyID)
+	utils.WriteSuccess(w, model)
+}
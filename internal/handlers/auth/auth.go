@@ -2,7 +2,9 @@ package auth
 
 import (
 	"encoding/json"
+	"log"
 	"net/http"
+	"time"
 
 	"github.com/VinVorteX/NoBurn/internal/models"
 	"github.com/VinVorteX/NoBurn/internal/repository"
@@ -40,6 +42,11 @@ func Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	activityRepo := repository.NewUserActivityRepository().ForTenant(repository.WithTenant(r.Context(), user.CompanyID))
+	if err := activityRepo.RecordLogin(user.ID, time.Now()); err != nil {
+		log.Printf("❌ Failed to record login for user %d: %v", user.ID, err)
+	}
+
 	token, _ := utils.GenerateToken(user.ID, user.Email)
 	
 	utils.WriteSuccess(w, map[string]interface{}{
@@ -79,7 +86,7 @@ func Register(w http.ResponseWriter, r *http.Request) {
 		Email:     req.Email,
 		Password:  hashedPassword,
 		Name:      req.Name,
-		Role:      "hr_admin",
+		Role:      models.RoleHRAdmin,
 		CompanyID: company.ID,
 	}
 	if err := userRepo.Create(user); err != nil {
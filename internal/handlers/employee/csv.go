@@ -0,0 +1,65 @@
+package employee
+
+import (
+	"strings"
+)
+
+// employeeRow is one line of a bulk upload CSV
+type employeeRow struct {
+	Email        string
+	Name         string
+	Department   string
+	Team         string
+	ManagerEmail string
+}
+
+var csvColumns = map[string]string{
+	"email":         "email",
+	"name":          "name",
+	"department":    "department",
+	"team":          "team",
+	"manager_email": "manager_email",
+	"manager":       "manager_email",
+}
+
+// parseEmployeeRows maps CSV records onto rows using the header line. Headers that
+// don't name an email column fall back to the original email,name layout.
+func parseEmployeeRows(records [][]string) []employeeRow {
+	if len(records) == 0 {
+		return nil
+	}
+
+	index := map[string]int{}
+	for i, header := range records[0] {
+		key := strings.ToLower(strings.TrimSpace(header))
+		key = strings.ReplaceAll(key, " ", "_")
+		if column, ok := csvColumns[key]; ok {
+			if _, seen := index[column]; !seen {
+				index[column] = i
+			}
+		}
+	}
+	if _, ok := index["email"]; !ok {
+		index = map[string]int{"email": 0, "name": 1}
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := index[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	rows := make([]employeeRow, 0, len(records)-1)
+	for _, record := range records[1:] {
+		rows = append(rows, employeeRow{
+			Email:        field(record, "email"),
+			Name:         field(record, "name"),
+			Department:   field(record, "department"),
+			Team:         field(record, "team"),
+			ManagerEmail: strings.ToLower(field(record, "manager_email")),
+		})
+	}
+	return rows
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/VinVorteX/NoBurn/internal/models"
+	middlewareAuth "github.com/VinVorteX/NoBurn/internal/middleware/auth"
+	"github.com/VinVorteX/NoBurn/internal/policy"
 	"github.com/VinVorteX/NoBurn/internal/repository"
 	"github.com/VinVorteX/NoBurn/internal/utils"
 )
@@ -16,6 +18,16 @@ import (
 type AddEmployeeRequest struct {
 	Email string `json:"email"`
 	Name  string `json:"name"`
+	Role  string `json:"role"` // defaults to employee
+	Assignment
+}
+
+// UpdateEmployeeRequest is decoded over the employee's current placement, so org
+// chart fields left out of the body keep their values and an explicit null clears them
+type UpdateEmployeeRequest struct {
+	Name string `json:"name"`
+	Role string `json:"role"` // left unchanged when empty
+	Assignment
 }
 
 // AddEmployee - Admin adds single employee
@@ -26,11 +38,14 @@ func AddEmployee(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userID := r.Context().Value("userID").(uint)
-	userRepo := repository.NewUserRepository()
-	admin, err := userRepo.GetByID(userID)
-	if err != nil || admin.Role != "hr_admin" {
-		utils.WriteError(w, http.StatusForbidden, "Only admins can add employees")
+	admin := middlewareAuth.CurrentUser(r)
+	userRepo := repository.NewUserRepository().ForTenant(r.Context())
+
+	if req.Role == "" {
+		req.Role = models.RoleEmployee
+	}
+	if !models.IsValidRole(req.Role) {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid role")
 		return
 	}
 
@@ -40,9 +55,13 @@ func AddEmployee(w http.ResponseWriter, r *http.Request) {
 		Email:     req.Email,
 		Password:  tempPassword,
 		Name:      req.Name,
-		Role:      "employee",
+		Role:      req.Role,
 		CompanyID: admin.CompanyID,
 	}
+	if err := assign(r.Context(), userRepo, employee, req.Assignment); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	if err := userRepo.Create(employee); err != nil {
 		utils.WriteError(w, http.StatusInternalServerError, "Failed to create employee")
@@ -58,13 +77,8 @@ func AddEmployee(w http.ResponseWriter, r *http.Request) {
 
 // BulkUploadEmployees - Admin uploads CSV with employees
 func BulkUploadEmployees(w http.ResponseWriter, r *http.Request) {
-	userID := r.Context().Value("userID").(uint)
-	userRepo := repository.NewUserRepository()
-	admin, err := userRepo.GetByID(userID)
-	if err != nil || admin.Role != "hr_admin" {
-		utils.WriteError(w, http.StatusForbidden, "Only admins can upload employees")
-		return
-	}
+	admin := middlewareAuth.CurrentUser(r)
+	userRepo := repository.NewUserRepository().ForTenant(r.Context())
 
 	if err := r.ParseMultipartForm(10 << 20); err != nil {
 		utils.WriteError(w, http.StatusBadRequest, "Failed to parse form")
@@ -93,32 +107,54 @@ func BulkUploadEmployees(w http.ResponseWriter, r *http.Request) {
 	tempPassword, _ := utils.HashPassword("temp123")
 	successCount := 0
 	failedEmails := []string{}
-
-	for i, record := range records[1:] {
-		if len(record) < 2 {
-			continue
-		}
-
-		email := strings.TrimSpace(record[0])
-		name := strings.TrimSpace(record[1])
-
-		if email == "" || name == "" {
-			failedEmails = append(failedEmails, email)
+	departmentRepo := repository.NewDepartmentRepository().ForTenant(r.Context())
+	teamRepo := repository.NewTeamRepository().ForTenant(r.Context())
+	created := map[string]*models.User{}
+	managers := map[string]string{}
+
+	for i, row := range parseEmployeeRows(records) {
+		if row.Email == "" || row.Name == "" {
+			failedEmails = append(failedEmails, row.Email)
 			continue
 		}
 
 		employee := &models.User{
-			Email:     email,
+			Email:     row.Email,
 			Password:  tempPassword,
-			Name:      name,
-			Role:      "employee",
+			Name:      row.Name,
+			Role:      models.RoleEmployee,
 			CompanyID: admin.CompanyID,
 		}
 
+		// Departments and teams named in the file are created on first use
+		if row.Department != "" {
+			department, err := departmentRepo.FirstOrCreate(admin.CompanyID, row.Department)
+			if err != nil {
+				failedEmails = append(failedEmails, row.Email)
+				continue
+			}
+			employee.DepartmentID = &department.ID
+		}
+		if row.Team != "" {
+			team, err := teamRepo.FirstOrCreate(admin.CompanyID, row.Team, employee.DepartmentID)
+			if err != nil {
+				failedEmails = append(failedEmails, row.Email)
+				continue
+			}
+			employee.TeamID = &team.ID
+			if employee.DepartmentID == nil {
+				employee.DepartmentID = team.DepartmentID
+			}
+		}
+
 		if err := userRepo.Create(employee); err != nil {
-			failedEmails = append(failedEmails, email)
+			failedEmails = append(failedEmails, row.Email)
 		} else {
 			successCount++
+			created[strings.ToLower(row.Email)] = employee
+			if row.ManagerEmail != "" {
+				managers[strings.ToLower(row.Email)] = row.ManagerEmail
+			}
 		}
 
 		if i >= 999 {
@@ -126,21 +162,46 @@ func BulkUploadEmployees(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Managers are linked once every row exists so they can appear anywhere in the file
+	failedManagers := []string{}
+	for email, managerEmail := range managers {
+		employee := created[email]
+		manager, ok := created[managerEmail]
+		if !ok {
+			manager, err = userRepo.GetByEmail(managerEmail)
+			if err != nil || manager.CompanyID != admin.CompanyID {
+				failedManagers = append(failedManagers, employee.Email)
+				continue
+			}
+		}
+
+		a := Assignment{DepartmentID: employee.DepartmentID, TeamID: employee.TeamID, ManagerID: &manager.ID}
+		if err := assign(r.Context(), userRepo, employee, a); err != nil {
+			failedManagers = append(failedManagers, employee.Email)
+			continue
+		}
+		if err := userRepo.Update(employee); err != nil {
+			failedManagers = append(failedManagers, employee.Email)
+		}
+	}
+
 	utils.WriteSuccess(w, map[string]interface{}{
-		"message":       "Bulk upload completed",
-		"success_count": successCount,
-		"failed_count":  len(failedEmails),
-		"failed_emails": failedEmails,
+		"message":              "Bulk upload completed",
+		"success_count":        successCount,
+		"failed_count":         len(failedEmails),
+		"failed_emails":        failedEmails,
+		"failed_manager_links": failedManagers,
 	})
 }
 
-// GetEmployees - List all employees in company
+// GetEmployees - List employees in company; people managers only see their reports
 func GetEmployees(w http.ResponseWriter, r *http.Request) {
-	userID := r.Context().Value("userID").(uint)
-	userRepo := repository.NewUserRepository()
-	user, err := userRepo.GetByID(userID)
+	user := middlewareAuth.CurrentUser(r)
+	userRepo := repository.NewUserRepository().ForTenant(r.Context())
+
+	scope, err := policy.ScopeFor(r.Context(), user)
 	if err != nil {
-		utils.WriteError(w, http.StatusUnauthorized, "User not found")
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to resolve visibility")
 		return
 	}
 
@@ -150,7 +211,62 @@ func GetEmployees(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	utils.WriteSuccess(w, employees)
+	utils.WriteSuccess(w, scope.Users(employees))
+}
+
+// UpdateEmployee - Admin renames an employee or moves them in the org chart
+func UpdateEmployee(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "employeeID"), 10, 32)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid employee ID")
+		return
+	}
+
+	admin := middlewareAuth.CurrentUser(r)
+	userRepo := repository.NewUserRepository().ForTenant(r.Context())
+
+	employee, err := userRepo.GetByID(uint(id))
+	if err != nil || employee.CompanyID != admin.CompanyID {
+		utils.WriteError(w, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	current := assignmentOf(employee)
+	req := UpdateEmployeeRequest{Assignment: assignmentOf(employee)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	// Moving teams without naming a department follows the new team's department
+	if req.TeamID != nil && !sameID(req.TeamID, current.TeamID) && sameID(req.DepartmentID, current.DepartmentID) {
+		req.DepartmentID = nil
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		employee.Name = name
+	}
+	if req.Role != "" && req.Role != employee.Role {
+		if employee.ID == admin.ID {
+			utils.WriteError(w, http.StatusBadRequest, "You cannot change your own role")
+			return
+		}
+		if !models.IsValidRole(req.Role) {
+			utils.WriteError(w, http.StatusBadRequest, "Invalid role")
+			return
+		}
+		employee.Role = req.Role
+	}
+	if err := assign(r.Context(), userRepo, employee, req.Assignment); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := userRepo.Update(employee); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to update employee")
+		return
+	}
+
+	utils.WriteSuccess(w, employee)
 }
 
 // DeleteEmployee - Delete an employee
@@ -167,13 +283,8 @@ func DeleteEmployee(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userID := r.Context().Value("userID").(uint)
-	userRepo := repository.NewUserRepository()
-	admin, err := userRepo.GetByID(userID)
-	if err != nil || admin.Role != "hr_admin" {
-		utils.WriteError(w, http.StatusForbidden, "Only admins can delete employees")
-		return
-	}
+	admin := middlewareAuth.CurrentUser(r)
+	userRepo := repository.NewUserRepository().ForTenant(r.Context())
 
 	// Get employee to verify they're in same company
 	employee, err := userRepo.GetByID(uint(id))
@@ -187,7 +298,7 @@ func DeleteEmployee(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if employee.Role == "hr_admin" {
+	if employee.Role == models.RoleHRAdmin {
 		utils.WriteError(w, http.StatusForbidden, "Cannot delete admin users")
 		return
 	}
@@ -199,3 +310,35 @@ func DeleteEmployee(w http.ResponseWriter, r *http.Request) {
 
 	utils.WriteSuccess(w, map[string]string{"message": "Employee deleted successfully"})
 }
+
+// GetDirectReports - List the employees reporting directly to a manager
+func GetDirectReports(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "employeeID"), 10, 32)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid employee ID")
+		return
+	}
+
+	user := middlewareAuth.CurrentUser(r)
+	userRepo := repository.NewUserRepository().ForTenant(r.Context())
+
+	scope, err := policy.ScopeFor(r.Context(), user)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to resolve visibility")
+		return
+	}
+
+	manager, err := userRepo.GetByID(uint(id))
+	if err != nil || manager.CompanyID != user.CompanyID || (manager.ID != user.ID && !scope.Includes(manager.ID)) {
+		utils.WriteError(w, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	reports, err := userRepo.GetDirectReports(manager.ID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch reports")
+		return
+	}
+
+	utils.WriteSuccess(w, reports)
+}
@@ -0,0 +1,128 @@
+package employee
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/VinVorteX/NoBurn/internal/models"
+	middlewareAuth "github.com/VinVorteX/NoBurn/internal/middleware/auth"
+	"github.com/VinVorteX/NoBurn/internal/policy"
+	"github.com/VinVorteX/NoBurn/internal/repository"
+	"github.com/VinVorteX/NoBurn/internal/services"
+	"github.com/VinVorteX/NoBurn/internal/utils"
+)
+
+type OffboardRequest struct {
+	ExitDate  string `json:"exit_date"` // YYYY-MM-DD, defaults to today
+	ExitType  string `json:"exit_type"` // voluntary or involuntary
+	Reason    string `json:"reason"`
+	Regretted bool   `json:"regretted"`
+}
+
+// ExitRecord is an exit with the last risk score predicted before the employee left
+type ExitRecord struct {
+	models.EmployeeExit
+	PredictedRisk *float64 `json:"predicted_risk"`
+}
+
+// OffboardEmployee records that an employee left and removes them from the active
+// workforce, keeping their survey and risk history
+func OffboardEmployee(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "employeeID"), 10, 32)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid employee ID")
+		return
+	}
+
+	var req OffboardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	if !models.IsValidExitType(req.ExitType) {
+		utils.WriteError(w, http.StatusBadRequest, "exit_type must be voluntary or involuntary")
+		return
+	}
+
+	exitDate := time.Now()
+	if req.ExitDate != "" {
+		if exitDate, err = time.Parse("2006-01-02", req.ExitDate); err != nil {
+			utils.WriteError(w, http.StatusBadRequest, "exit_date must be YYYY-MM-DD")
+			return
+		}
+		if exitDate.After(time.Now()) {
+			utils.WriteError(w, http.StatusBadRequest, "exit_date cannot be in the future")
+			return
+		}
+	}
+
+	admin := middlewareAuth.CurrentUser(r)
+	userRepo := repository.NewUserRepository().ForTenant(r.Context())
+
+	employee, err := userRepo.GetByID(uint(id))
+	if err != nil || employee.CompanyID != admin.CompanyID {
+		utils.WriteError(w, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	if employee.ID == admin.ID {
+		utils.WriteError(w, http.StatusBadRequest, "You cannot offboard yourself")
+		return
+	}
+
+	exit := &models.EmployeeExit{
+		UserID:     employee.ID,
+		CompanyID:  employee.CompanyID,
+		ManagerID:  employee.ManagerID,
+		ExitDate:   exitDate,
+		ExitType:   req.ExitType,
+		Reason:     strings.TrimSpace(req.Reason),
+		Regretted:  req.Regretted,
+		RecordedBy: admin.ID,
+	}
+
+	if err := repository.NewExitRepository().ForTenant(r.Context()).Offboard(exit); err != nil {
+		log.Printf("❌ Failed to offboard employee %d: %v", employee.ID, err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to offboard employee")
+		return
+	}
+
+	log.Printf("👋 Employee %s offboarded (%s)", employee.Email, exit.ExitType)
+	exit.User = employee
+	utils.WriteSuccess(w, exit)
+}
+
+// GetExits lists the past year's exits with what the churn model predicted for each
+func GetExits(w http.ResponseWriter, r *http.Request) {
+	user := middlewareAuth.CurrentUser(r)
+
+	scope, err := policy.ScopeFor(r.Context(), user)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to resolve visibility")
+		return
+	}
+
+	exits, err := repository.NewExitRepository().ForTenant(r.Context()).GetByCompanyID(user.CompanyID, time.Now().Add(-services.AttritionWindow))
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch exits")
+		return
+	}
+
+	attritionRepo := repository.NewAttritionRepository().ForTenant(r.Context())
+	records := []ExitRecord{}
+	for _, exit := range scope.Exits(exits) {
+		record := ExitRecord{EmployeeExit: exit}
+		if risk, err := attritionRepo.GetLatestBefore(exit.UserID, exit.ExitDate); err == nil {
+			record.PredictedRisk = &risk.RiskScore
+		}
+		records = append(records, record)
+	}
+
+	utils.WriteSuccess(w, records)
+}
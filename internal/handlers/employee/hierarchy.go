@@ -0,0 +1,92 @@
+package employee
+
+import (
+	"context"
+	"errors"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+	"github.com/VinVorteX/NoBurn/internal/repository"
+)
+
+// Assignment places an employee in the org chart. Nil fields clear the assignment.
+type Assignment struct {
+	DepartmentID *uint `json:"department_id"`
+	TeamID       *uint `json:"team_id"`
+	ManagerID    *uint `json:"manager_id"`
+}
+
+// assign validates that the referenced department, team and manager belong to the
+// employee's company and that the manager would not create a reporting cycle.
+// A team without an explicit department places the employee in the team's department,
+// and an explicit department must be the team's own.
+func assign(ctx context.Context, userRepo *repository.UserRepository, employee *models.User, a Assignment) error {
+	if a.DepartmentID != nil {
+		department, err := repository.NewDepartmentRepository().ForTenant(ctx).GetByID(*a.DepartmentID)
+		if err != nil || department.CompanyID != employee.CompanyID {
+			return errors.New("department not found")
+		}
+	}
+
+	if a.TeamID != nil {
+		team, err := repository.NewTeamRepository().ForTenant(ctx).GetByID(*a.TeamID)
+		if err != nil || team.CompanyID != employee.CompanyID {
+			return errors.New("team not found")
+		}
+		if a.DepartmentID == nil {
+			a.DepartmentID = team.DepartmentID
+		} else if team.DepartmentID != nil && *team.DepartmentID != *a.DepartmentID {
+			return errors.New("team belongs to another department")
+		}
+	}
+
+	if a.ManagerID != nil {
+		if *a.ManagerID == employee.ID {
+			return errors.New("an employee cannot manage themselves")
+		}
+		manager, err := userRepo.GetByID(*a.ManagerID)
+		if err != nil || manager.CompanyID != employee.CompanyID {
+			return errors.New("manager not found")
+		}
+		if employee.ID != 0 {
+			reports, err := userRepo.GetReportIDs(employee.ID)
+			if err != nil {
+				return err
+			}
+			for _, id := range reports {
+				if id == manager.ID {
+					return errors.New("manager reports to this employee")
+				}
+			}
+		}
+	}
+
+	employee.DepartmentID = a.DepartmentID
+	employee.TeamID = a.TeamID
+	employee.ManagerID = a.ManagerID
+	return nil
+}
+
+// assignmentOf copies the employee's current placement. The IDs are copied too, since
+// decoding a number into a non-nil pointer writes through it.
+func assignmentOf(employee *models.User) Assignment {
+	return Assignment{
+		DepartmentID: copyID(employee.DepartmentID),
+		TeamID:       copyID(employee.TeamID),
+		ManagerID:    copyID(employee.ManagerID),
+	}
+}
+
+func copyID(id *uint) *uint {
+	if id == nil {
+		return nil
+	}
+	v := *id
+	return &v
+}
+
+func sameID(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,245 @@
+package org
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	middlewareAuth "github.com/VinVorteX/NoBurn/internal/middleware/auth"
+	"github.com/VinVorteX/NoBurn/internal/models"
+	"github.com/VinVorteX/NoBurn/internal/repository"
+	"github.com/VinVorteX/NoBurn/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+type DepartmentRequest struct {
+	Name string `json:"name"`
+}
+
+type TeamRequest struct {
+	Name         string `json:"name"`
+	DepartmentID *uint  `json:"department_id"`
+}
+
+// GetDepartments - List departments in the company
+func GetDepartments(w http.ResponseWriter, r *http.Request) {
+	user := middlewareAuth.CurrentUser(r)
+
+	departments, err := repository.NewDepartmentRepository().ForTenant(r.Context()).GetByCompanyID(user.CompanyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch departments")
+		return
+	}
+
+	utils.WriteSuccess(w, departments)
+}
+
+// CreateDepartment - Admin creates a department
+func CreateDepartment(w http.ResponseWriter, r *http.Request) {
+	var req DepartmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	admin := middlewareAuth.CurrentUser(r)
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		utils.WriteError(w, http.StatusBadRequest, "Department name is required")
+		return
+	}
+
+	department := &models.Department{CompanyID: admin.CompanyID, Name: name}
+	if err := repository.NewDepartmentRepository().ForTenant(r.Context()).Create(department); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to create department")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	utils.WriteSuccess(w, department)
+}
+
+// UpdateDepartment - Admin renames a department
+func UpdateDepartment(w http.ResponseWriter, r *http.Request) {
+	var req DepartmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	admin := middlewareAuth.CurrentUser(r)
+
+	departmentRepo := repository.NewDepartmentRepository().ForTenant(r.Context())
+	department, ok := loadDepartment(w, r, departmentRepo, admin.CompanyID)
+	if !ok {
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		utils.WriteError(w, http.StatusBadRequest, "Department name is required")
+		return
+	}
+
+	department.Name = name
+	if err := departmentRepo.Update(department); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to update department")
+		return
+	}
+
+	utils.WriteSuccess(w, department)
+}
+
+// DeleteDepartment - Admin deletes a department; its teams and employees are kept but unassigned
+func DeleteDepartment(w http.ResponseWriter, r *http.Request) {
+	admin := middlewareAuth.CurrentUser(r)
+
+	departmentRepo := repository.NewDepartmentRepository().ForTenant(r.Context())
+	department, ok := loadDepartment(w, r, departmentRepo, admin.CompanyID)
+	if !ok {
+		return
+	}
+
+	if err := departmentRepo.Delete(department.ID); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to delete department")
+		return
+	}
+
+	utils.WriteSuccess(w, map[string]string{"message": "Department deleted successfully"})
+}
+
+// GetTeams - List teams in the company
+func GetTeams(w http.ResponseWriter, r *http.Request) {
+	user := middlewareAuth.CurrentUser(r)
+
+	teams, err := repository.NewTeamRepository().ForTenant(r.Context()).GetByCompanyID(user.CompanyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch teams")
+		return
+	}
+
+	utils.WriteSuccess(w, teams)
+}
+
+// CreateTeam - Admin creates a team, optionally inside a department
+func CreateTeam(w http.ResponseWriter, r *http.Request) {
+	var req TeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	admin := middlewareAuth.CurrentUser(r)
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		utils.WriteError(w, http.StatusBadRequest, "Team name is required")
+		return
+	}
+	if !departmentInCompany(r, req.DepartmentID, admin.CompanyID) {
+		utils.WriteError(w, http.StatusBadRequest, "Department not found")
+		return
+	}
+
+	team := &models.Team{CompanyID: admin.CompanyID, Name: name, DepartmentID: req.DepartmentID}
+	if err := repository.NewTeamRepository().ForTenant(r.Context()).Create(team); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to create team")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	utils.WriteSuccess(w, team)
+}
+
+// UpdateTeam - Admin renames a team or moves it to another department
+func UpdateTeam(w http.ResponseWriter, r *http.Request) {
+	var req TeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	admin := middlewareAuth.CurrentUser(r)
+
+	teamRepo := repository.NewTeamRepository().ForTenant(r.Context())
+	team, ok := loadTeam(w, r, teamRepo, admin.CompanyID)
+	if !ok {
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		utils.WriteError(w, http.StatusBadRequest, "Team name is required")
+		return
+	}
+	if !departmentInCompany(r, req.DepartmentID, admin.CompanyID) {
+		utils.WriteError(w, http.StatusBadRequest, "Department not found")
+		return
+	}
+
+	team.Name = name
+	team.DepartmentID = req.DepartmentID
+	team.Department = nil
+	if err := teamRepo.Update(team); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to update team")
+		return
+	}
+
+	utils.WriteSuccess(w, team)
+}
+
+// DeleteTeam - Admin deletes a team; its employees are kept but unassigned
+func DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	admin := middlewareAuth.CurrentUser(r)
+
+	teamRepo := repository.NewTeamRepository().ForTenant(r.Context())
+	team, ok := loadTeam(w, r, teamRepo, admin.CompanyID)
+	if !ok {
+		return
+	}
+
+	if err := teamRepo.Delete(team.ID); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to delete team")
+		return
+	}
+
+	utils.WriteSuccess(w, map[string]string{"message": "Team deleted successfully"})
+}
+
+func loadDepartment(w http.ResponseWriter, r *http.Request, repo *repository.DepartmentRepository, companyID uint) (*models.Department, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "departmentID"), 10, 32)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid department ID")
+		return nil, false
+	}
+	department, err := repo.GetByID(uint(id))
+	if err != nil || department.CompanyID != companyID {
+		utils.WriteError(w, http.StatusNotFound, "Department not found")
+		return nil, false
+	}
+	return department, true
+}
+
+func loadTeam(w http.ResponseWriter, r *http.Request, repo *repository.TeamRepository, companyID uint) (*models.Team, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "teamID"), 10, 32)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid team ID")
+		return nil, false
+	}
+	team, err := repo.GetByID(uint(id))
+	if err != nil || team.CompanyID != companyID {
+		utils.WriteError(w, http.StatusNotFound, "Team not found")
+		return nil, false
+	}
+	return team, true
+}
+
+func departmentInCompany(r *http.Request, departmentID *uint, companyID uint) bool {
+	if departmentID == nil {
+		return true
+	}
+	department, err := repository.NewDepartmentRepository().ForTenant(r.Context()).GetByID(*departmentID)
+	return err == nil && department.CompanyID == companyID
+}
@@ -0,0 +1,150 @@
+package settings
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/VinVorteX/NoBurn/internal/models"
+	middlewareAuth "github.com/VinVorteX/NoBurn/internal/middleware/auth"
+	"github.com/VinVorteX/NoBurn/internal/repository"
+	"github.com/VinVorteX/NoBurn/internal/utils"
+)
+
+type AlertRuleRequest struct {
+	Name         string  `json:"name"`
+	Type         string  `json:"type"` // threshold, risk_increase or negative_streak
+	Threshold    float64 `json:"threshold"`
+	WindowDays   int     `json:"window_days"`
+	Count        int     `json:"count"`
+	CooldownDays *int    `json:"cooldown_days"` // defaults to 14
+	IsActive     *bool   `json:"is_active"`     // defaults to true
+}
+
+func (req AlertRuleRequest) apply(rule *models.AlertRule) {
+	rule.Name = strings.TrimSpace(req.Name)
+	rule.Type = req.Type
+	rule.Threshold = req.Threshold
+	rule.WindowDays = req.WindowDays
+	rule.Count = req.Count
+	if req.CooldownDays != nil {
+		rule.CooldownDays = *req.CooldownDays
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+}
+
+// GetAlertRules returns the company's alert rules. Until the first rule is created the
+// defaults apply, and they are returned with "defaults": true.
+func GetAlertRules(w http.ResponseWriter, r *http.Request) {
+	user := middlewareAuth.CurrentUser(r)
+
+	rules, err := repository.NewAlertRuleRepository().ForTenant(r.Context()).GetByCompanyID(user.CompanyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch alert rules")
+		return
+	}
+
+	defaults := len(rules) == 0
+	if defaults {
+		riskSettings, err := repository.NewRiskSettingsRepository().ForTenant(r.Context()).GetCurrent(user.CompanyID)
+		if err != nil {
+			utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch risk settings")
+			return
+		}
+		rules = models.DefaultAlertRules(user.CompanyID, riskSettings.HighRiskThreshold)
+	}
+
+	utils.WriteSuccess(w, map[string]interface{}{
+		"rules":    rules,
+		"defaults": defaults,
+	})
+}
+
+// CreateAlertRule adds a rule. Once a company has any rule, the defaults no longer apply.
+func CreateAlertRule(w http.ResponseWriter, r *http.Request) {
+	var req AlertRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	user := middlewareAuth.CurrentUser(r)
+
+	rule := &models.AlertRule{CompanyID: user.CompanyID, CooldownDays: 14, IsActive: true}
+	req.apply(rule)
+	if err := rule.Validate(); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := repository.NewAlertRuleRepository().ForTenant(r.Context()).Create(rule); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to create alert rule")
+		return
+	}
+
+	utils.WriteSuccess(w, rule)
+}
+
+func UpdateAlertRule(w http.ResponseWriter, r *http.Request) {
+	var req AlertRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	user := middlewareAuth.CurrentUser(r)
+
+	ruleRepo := repository.NewAlertRuleRepository().ForTenant(r.Context())
+	rule, ok := loadAlertRule(w, r, ruleRepo, user.CompanyID)
+	if !ok {
+		return
+	}
+
+	req.apply(rule)
+	if err := rule.Validate(); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ruleRepo.Update(rule); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to update alert rule")
+		return
+	}
+
+	utils.WriteSuccess(w, rule)
+}
+
+func DeleteAlertRule(w http.ResponseWriter, r *http.Request) {
+	user := middlewareAuth.CurrentUser(r)
+
+	ruleRepo := repository.NewAlertRuleRepository().ForTenant(r.Context())
+	rule, ok := loadAlertRule(w, r, ruleRepo, user.CompanyID)
+	if !ok {
+		return
+	}
+
+	if err := ruleRepo.Delete(rule.ID); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to delete alert rule")
+		return
+	}
+
+	utils.WriteSuccess(w, map[string]string{"message": "Alert rule deleted successfully"})
+}
+
+func loadAlertRule(w http.ResponseWriter, r *http.Request, repo *repository.AlertRuleRepository, companyID uint) (*models.AlertRule, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "ruleID"), 10, 32)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid rule ID")
+		return nil, false
+	}
+	rule, err := repo.GetByID(uint(id))
+	if err != nil || rule.CompanyID != companyID {
+		utils.WriteError(w, http.StatusNotFound, "Alert rule not found")
+		return nil, false
+	}
+	return rule, true
+}
@@ -0,0 +1,74 @@
+package settings
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	middlewareAuth "github.com/VinVorteX/NoBurn/internal/middleware/auth"
+	"github.com/VinVorteX/NoBurn/internal/repository"
+	"github.com/VinVorteX/NoBurn/internal/utils"
+)
+
+// GetRiskSettings returns the company's current thresholds, weights and factor
+// cutoffs. Version 0 means the defaults are in use.
+func GetRiskSettings(w http.ResponseWriter, r *http.Request) {
+	user := middlewareAuth.CurrentUser(r)
+
+	settings, err := repository.NewRiskSettingsRepository().ForTenant(r.Context()).GetCurrent(user.CompanyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch risk settings")
+		return
+	}
+
+	utils.WriteSuccess(w, settings)
+}
+
+// UpdateRiskSettings saves a new version. Fields left out of the body keep their
+// current values.
+func UpdateRiskSettings(w http.ResponseWriter, r *http.Request) {
+	user := middlewareAuth.CurrentUser(r)
+
+	settingsRepo := repository.NewRiskSettingsRepository().ForTenant(r.Context())
+	settings, err := settingsRepo.GetCurrent(user.CompanyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch risk settings")
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(settings); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	// Bookkeeping fields are not the client's to set
+	settings.CompanyID = user.CompanyID
+	settings.CreatedBy = user.ID
+	settings.CreatedAt = time.Time{}
+
+	if err := settings.Validate(); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := settingsRepo.Save(settings); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to save risk settings")
+		return
+	}
+
+	log.Printf("⚙️ Risk settings v%d saved for company %d", settings.Version, user.CompanyID)
+	utils.WriteSuccess(w, settings)
+}
+
+// GetRiskSettingsHistory lists every saved version, newest first
+func GetRiskSettingsHistory(w http.ResponseWriter, r *http.Request) {
+	user := middlewareAuth.CurrentUser(r)
+
+	history, err := repository.NewRiskSettingsRepository().ForTenant(r.Context()).GetHistory(user.CompanyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch risk settings history")
+		return
+	}
+
+	utils.WriteSuccess(w, history)
+}
@@ -3,8 +3,14 @@ package settings
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
+	"github.com/VinVorteX/NoBurn/internal/config"
+	middlewareAuth "github.com/VinVorteX/NoBurn/internal/middleware/auth"
+	"github.com/VinVorteX/NoBurn/internal/models"
 	"github.com/VinVorteX/NoBurn/internal/repository"
+	"github.com/VinVorteX/NoBurn/internal/services"
+	"github.com/VinVorteX/NoBurn/internal/services/sentiment"
 	"github.com/VinVorteX/NoBurn/internal/utils"
 )
 
@@ -22,15 +28,9 @@ func UpdateSMTPSettings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userID := r.Context().Value("userID").(uint)
-	userRepo := repository.NewUserRepository()
-	user, err := userRepo.GetByID(userID)
-	if err != nil || user.Role != "hr_admin" {
-		utils.WriteError(w, http.StatusForbidden, "Only admins can update SMTP settings")
-		return
-	}
+	user := middlewareAuth.CurrentUser(r)
 
-	companyRepo := repository.NewCompanyRepository()
+	companyRepo := repository.NewCompanyRepository().ForTenant(r.Context())
 	company, err := companyRepo.GetByID(user.CompanyID)
 	if err != nil {
 		utils.WriteError(w, http.StatusNotFound, "Company not found")
@@ -56,15 +56,9 @@ func UpdateSMTPSettings(w http.ResponseWriter, r *http.Request) {
 }
 
 func GetSMTPSettings(w http.ResponseWriter, r *http.Request) {
-	userID := r.Context().Value("userID").(uint)
-	userRepo := repository.NewUserRepository()
-	user, err := userRepo.GetByID(userID)
-	if err != nil {
-		utils.WriteError(w, http.StatusUnauthorized, "User not found")
-		return
-	}
+	user := middlewareAuth.CurrentUser(r)
 
-	companyRepo := repository.NewCompanyRepository()
+	companyRepo := repository.NewCompanyRepository().ForTenant(r.Context())
 	company, err := companyRepo.GetByID(user.CompanyID)
 	if err != nil {
 		utils.WriteError(w, http.StatusNotFound, "Company not found")
@@ -78,3 +72,112 @@ func GetSMTPSettings(w http.ResponseWriter, r *http.Request) {
 		"smtp_configured": company.SMTPUser != "",
 	})
 }
+
+// SentimentProviders replaces the company's provider chains when present; an
+// empty object reverts to the server's SENTIMENT_PROVIDERS. AspectKeywords works the
+// same way for the company's own aspect keywords.
+type UpdateCompanyRequest struct {
+	Language           string                 `json:"language"`
+	Timezone           string                 `json:"timezone"`
+	SentimentProviders *models.ProviderChains `json:"sentiment_providers"`
+	AspectKeywords     *models.AspectKeywords `json:"aspect_keywords"`
+}
+
+func UpdateCompanySettings(w http.ResponseWriter, r *http.Request) {
+	var req UpdateCompanyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	if req.Timezone != "" {
+		if _, err := time.LoadLocation(req.Timezone); err != nil {
+			utils.WriteError(w, http.StatusBadRequest, "Invalid timezone")
+			return
+		}
+	}
+
+	mlService, err := services.NewSentimentService(config.AppConfig)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Sentiment providers are misconfigured")
+		return
+	}
+	if req.SentimentProviders != nil {
+		if err := mlService.Registry().Validate(sentiment.Chains(*req.SentimentProviders)); err != nil {
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if req.AspectKeywords != nil {
+		if err := sentiment.ValidateAspectKeywords(*req.AspectKeywords); err != nil {
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	user := middlewareAuth.CurrentUser(r)
+
+	companyRepo := repository.NewCompanyRepository().ForTenant(r.Context())
+	company, err := companyRepo.GetByID(user.CompanyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusNotFound, "Company not found")
+		return
+	}
+
+	if req.Language != "" {
+		company.Language = req.Language
+	}
+	if req.Timezone != "" {
+		company.Timezone = req.Timezone
+	}
+	if req.SentimentProviders != nil {
+		company.SentimentProviders = *req.SentimentProviders
+		if len(company.SentimentProviders) == 0 {
+			company.SentimentProviders = nil
+		}
+	}
+	if req.AspectKeywords != nil {
+		company.AspectKeywords = *req.AspectKeywords
+		if len(company.AspectKeywords) == 0 {
+			company.AspectKeywords = nil
+		}
+	}
+
+	if err := companyRepo.Update(company); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to update settings")
+		return
+	}
+
+	utils.WriteSuccess(w, map[string]interface{}{
+		"message":             "Company settings updated successfully",
+		"language":            company.Language,
+		"timezone":            company.Timezone,
+		"sentiment_providers": company.SentimentProviders,
+		"aspect_keywords":     company.AspectKeywords,
+	})
+}
+
+func GetCompanySettings(w http.ResponseWriter, r *http.Request) {
+	user := middlewareAuth.CurrentUser(r)
+
+	companyRepo := repository.NewCompanyRepository().ForTenant(r.Context())
+	company, err := companyRepo.GetByID(user.CompanyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusNotFound, "Company not found")
+		return
+	}
+
+	available := []string{}
+	if mlService, err := services.NewSentimentService(config.AppConfig); err == nil {
+		available = mlService.Registry().Providers()
+	}
+
+	utils.WriteSuccess(w, map[string]interface{}{
+		"language":            company.Language,
+		"timezone":            company.Timezone,
+		"sentiment_providers": company.SentimentProviders,
+		"aspect_keywords":     company.AspectKeywords,
+		"available_providers": available,
+	})
+}
@@ -0,0 +1,133 @@
+package survey
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+	middlewareAuth "github.com/VinVorteX/NoBurn/internal/middleware/auth"
+	"github.com/VinVorteX/NoBurn/internal/repository"
+	"github.com/VinVorteX/NoBurn/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+// CreateScheduleRequest either points at an existing survey to use as the template
+// (SurveyID) or defines the template inline with Title and Questions.
+type CreateScheduleRequest struct {
+	SurveyID      uint                `json:"survey_id"`
+	Title         string              `json:"title"`
+	Questions     models.QuestionList `json:"questions"`
+	IsAnonymous   bool                `json:"is_anonymous"`
+	Cronspec      string              `json:"cronspec"`
+	IntervalWeeks int                 `json:"interval_weeks"`
+	Weekday       int                 `json:"weekday"`
+	Hour          int                 `json:"hour"`
+	Minute        int                 `json:"minute"`
+	Timezone      string              `json:"timezone"`
+}
+
+// CreateSurveySchedule - Admin sets up a recurring pulse survey
+func CreateSurveySchedule(w http.ResponseWriter, r *http.Request) {
+	var req CreateScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	admin := middlewareAuth.CurrentUser(r)
+
+	schedule := &models.SurveySchedule{
+		CompanyID:     admin.CompanyID,
+		Cronspec:      req.Cronspec,
+		IntervalWeeks: req.IntervalWeeks,
+		Weekday:       req.Weekday,
+		Hour:          req.Hour,
+		Minute:        req.Minute,
+		Timezone:      req.Timezone,
+		IsActive:      true,
+	}
+	if schedule.Cronspec == "" && schedule.IntervalWeeks == 0 {
+		schedule.IntervalWeeks = 1
+	}
+	if err := schedule.Validate(); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	surveyRepo := repository.NewSurveyRepository().ForTenant(r.Context())
+	if req.SurveyID != 0 {
+		template, err := surveyRepo.GetByID(req.SurveyID)
+		if err != nil || template.CompanyID != admin.CompanyID {
+			utils.WriteError(w, http.StatusNotFound, "Survey not found")
+			return
+		}
+		schedule.SurveyID = template.ID
+	} else {
+		if err := req.Questions.Validate(); err != nil {
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		template := &models.Survey{
+			CompanyID:   admin.CompanyID,
+			Title:       req.Title,
+			Questions:   req.Questions,
+			IsActive:    false,
+			IsTemplate:  true,
+			IsAnonymous: req.IsAnonymous,
+		}
+		if err := surveyRepo.Create(template); err != nil {
+			utils.WriteError(w, http.StatusInternalServerError, "Failed to create survey template")
+			return
+		}
+		schedule.SurveyID = template.ID
+	}
+
+	scheduleRepo := repository.NewSurveyScheduleRepository().ForTenant(r.Context())
+	if err := scheduleRepo.Create(schedule); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to create schedule")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	utils.WriteSuccess(w, schedule)
+}
+
+// GetSurveySchedules - List recurring surveys for the company
+func GetSurveySchedules(w http.ResponseWriter, r *http.Request) {
+	user := middlewareAuth.CurrentUser(r)
+
+	scheduleRepo := repository.NewSurveyScheduleRepository().ForTenant(r.Context())
+	schedules, err := scheduleRepo.GetByCompanyID(user.CompanyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch schedules")
+		return
+	}
+
+	utils.WriteSuccess(w, schedules)
+}
+
+// DeleteSurveySchedule - Stop a recurring survey; surveys already sent are kept
+func DeleteSurveySchedule(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "scheduleID"), 10, 32)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid schedule ID")
+		return
+	}
+
+	admin := middlewareAuth.CurrentUser(r)
+
+	scheduleRepo := repository.NewSurveyScheduleRepository().ForTenant(r.Context())
+	schedule, err := scheduleRepo.GetByID(uint(id))
+	if err != nil || schedule.CompanyID != admin.CompanyID {
+		utils.WriteError(w, http.StatusNotFound, "Schedule not found")
+		return
+	}
+
+	if err := scheduleRepo.Delete(schedule.ID); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to delete schedule")
+		return
+	}
+
+	utils.WriteSuccess(w, map[string]string{"message": "Schedule deleted successfully"})
+}
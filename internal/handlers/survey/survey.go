@@ -1,29 +1,40 @@
 package survey
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"math/rand"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/VinVorteX/NoBurn/internal/config"
 	"github.com/VinVorteX/NoBurn/internal/models"
+	middlewareAuth "github.com/VinVorteX/NoBurn/internal/middleware/auth"
 	"github.com/VinVorteX/NoBurn/internal/repository"
-	"github.com/VinVorteX/NoBurn/internal/services/sentiment"
+	"github.com/VinVorteX/NoBurn/internal/services"
 	"github.com/VinVorteX/NoBurn/internal/worker"
 	"github.com/VinVorteX/NoBurn/internal/utils"
+	"gorm.io/gorm"
 )
 
 type CreateSurveyRequest struct {
-	Title     string   `json:"title"`
-	Questions []string `json:"questions"`
+	Title       string              `json:"title"`
+	Questions   models.QuestionList `json:"questions"`
+	IsAnonymous bool                `json:"is_anonymous"`
 }
 
+// Answers holds one typed answer per question. Responses is the older plain-text
+// form and is only used when Answers is empty.
 type SurveyResponseRequest struct {
-	SurveyID  uint     `json:"survey_id"`
-	Responses []string `json:"responses"`
+	SurveyID  uint              `json:"survey_id"`
+	Answers   models.AnswerList `json:"answers"`
+	Responses []string          `json:"responses"`
 }
 
 func CreateSurvey(w http.ResponseWriter, r *http.Request) {
@@ -33,22 +44,31 @@ func CreateSurvey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userID := r.Context().Value("userID").(uint)
-	userRepo := repository.NewUserRepository()
-	user, err := userRepo.GetByID(userID)
-	if err != nil {
-		utils.WriteError(w, http.StatusUnauthorized, "User not found")
+	if err := req.Questions.Validate(); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	user := middlewareAuth.CurrentUser(r)
+	userRepo := repository.NewUserRepository().ForTenant(r.Context())
+
 	survey := &models.Survey{
-		CompanyID: user.CompanyID,
-		Title:     req.Title,
-		Questions: models.StringArray(req.Questions),
-		IsActive:  true,
+		CompanyID:   user.CompanyID,
+		Title:       req.Title,
+		Questions:   req.Questions,
+		IsActive:    true,
+		IsAnonymous: req.IsAnonymous,
+	}
+	if survey.IsAnonymous {
+		salt, err := utils.GenerateOpaqueToken()
+		if err != nil {
+			utils.WriteError(w, http.StatusInternalServerError, "Failed to create survey")
+			return
+		}
+		survey.AnonymitySalt = salt
 	}
 
-	surveyRepo := repository.NewSurveyRepository()
+	surveyRepo := repository.NewSurveyRepository().ForTenant(r.Context())
 	if err := surveyRepo.Create(survey); err != nil {
 		utils.WriteError(w, http.StatusInternalServerError, "Failed to create survey")
 		return
@@ -59,19 +79,7 @@ func CreateSurvey(w http.ResponseWriter, r *http.Request) {
 	if err == nil {
 		workerClient := getWorkerClient()
 		if workerClient != nil {
-			sentCount := 0
-			for _, employee := range employees {
-				// Skip admins
-				if employee.Role == "hr_admin" {
-					continue
-				}
-				log.Printf("📧 Sending survey invitation to %s", employee.Email)
-				if err := workerClient.EnqueueSurveyInvitation(survey.ID, employee.ID, employee.Email); err != nil {
-					log.Printf("❌ Failed to enqueue survey invitation for %s: %v", employee.Email, err)
-				} else {
-					sentCount++
-				}
-			}
+			sentCount := workerClient.EnqueueSurveyInvitations(survey.ID, employees)
 			log.Printf("✅ Survey invitations enqueued for %d employees", sentCount)
 		}
 	}
@@ -88,61 +96,104 @@ func SubmitResponse(w http.ResponseWriter, r *http.Request) {
 	}
 
 	userID := r.Context().Value("userID").(uint)
-	
-	// Get user's company language
-	userRepo := repository.NewUserRepository()
-	user, _ := userRepo.GetByID(userID)
-	companyRepo := repository.NewCompanyRepository()
-	company, _ := companyRepo.GetByID(user.CompanyID)
-	language := "en"
-	if company != nil && company.Language != "" {
-		language = company.Language
-	}
-
-	// Combine responses for sentiment analysis
-	combinedText := strings.Join(req.Responses, " ")
-
-	// Analyze sentiment using IndicBERT (with fallback to rule-based)
-	log.Printf("Analyzing: '%s' in language: %s", combinedText, language)
-	mlService := sentiment.NewMLService(config.AppConfig.HuggingFaceToken)
-	sentimentScore, err := mlService.AnalyzeSentiment(combinedText, language)
+
+	surveyRepo := repository.NewSurveyRepository().ForTenant(r.Context())
+	survey, err := surveyRepo.GetByID(req.SurveyID)
 	if err != nil {
-		log.Printf("⚠️ ML error: %v, using rule-based", err)
-		sentimentScore = sentiment.AnalyzeSentiment(combinedText, language)
+		utils.WriteError(w, http.StatusNotFound, "Survey not found")
+		return
+	}
+
+	answers := collectAnswers(req.Answers, req.Responses)
+	if err := survey.Questions.ValidateAnswers(answers); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
 	}
-	log.Printf("✅ Final Sentiment: %f", sentimentScore)
 
-	// Save response with sentiment
+	// Save right away; the worker detects the language and scores sentiment
 	response := &models.SurveyResponse{
-		SurveyID:  req.SurveyID,
-		UserID:    userID,
-		Responses: models.StringArray(req.Responses),
-		Sentiment: sentimentScore,
+		SurveyID:        req.SurveyID,
+		Responses:       answers.Strings(),
+		Answers:         answers,
+		SentimentStatus: models.SentimentPending,
 	}
 
-	surveyRepo := repository.NewSurveyRepository()
-	if err := surveyRepo.CreateResponse(response); err != nil {
-		utils.WriteError(w, http.StatusInternalServerError, "Failed to save response")
+	if survey.IsAnonymous {
+		err = surveyRepo.CreateAnonymousResponse(participationFor(survey, userID), response)
+	} else {
+		response.UserID = &userID
+		err = surveyRepo.CreateResponse(response)
+	}
+	if errors.Is(err, repository.ErrAlreadyParticipated) {
+		utils.WriteError(w, http.StatusConflict, "Survey has already been submitted")
 		return
 	}
-	
-	// Enqueue background job for churn calculation
-	workerClient := getWorkerClient()
-	if workerClient != nil {
-		log.Printf("📤 Enqueueing churn calculation for user %d", userID)
-		if err := workerClient.EnqueueChurnCalculation(userID, user.CompanyID); err != nil {
-			log.Printf("❌ Failed to enqueue churn calculation: %v", err)
-		} else {
-			log.Printf("✅ Churn calculation job enqueued successfully")
-		}
-	} else {
-		log.Printf("⚠️ Worker client is nil, skipping background job")
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to save response")
+		return
 	}
+	recordResponse(r.Context(), survey, userID, false)
+	enqueueScoring(response)
 
 	w.WriteHeader(http.StatusCreated)
 	utils.WriteSuccess(w, response)
 }
 
+// participationFor builds the marker that stops a user answering an anonymous survey twice
+func participationFor(survey *models.Survey, userID uint) *models.SurveyParticipation {
+	return &models.SurveyParticipation{
+		SurveyID: survey.ID,
+		Marker:   utils.ParticipationMarker(config.AppConfig.AnonymitySecret, survey.AnonymitySalt, userID),
+	}
+}
+
+// recordResponse updates the activity the churn model reads. Only responses that
+// redeemed an invitation count as answered, and anonymous ones carry no timestamp.
+func recordResponse(ctx context.Context, survey *models.Survey, userID uint, invited bool) {
+	var at *time.Time
+	if !survey.IsAnonymous {
+		now := time.Now()
+		at = &now
+	}
+	if err := repository.NewUserActivityRepository().ForTenant(ctx).RecordResponse(userID, at, invited); err != nil {
+		log.Printf("❌ Failed to record response activity for user %d: %v", userID, err)
+	}
+}
+
+// collectAnswers prefers typed answers and falls back to the plain-text responses
+func collectAnswers(answers models.AnswerList, responses []string) models.AnswerList {
+	if len(answers) > 0 {
+		return answers
+	}
+	result := make(models.AnswerList, len(responses))
+	for i, text := range responses {
+		result[i] = models.Answer{Text: text}
+	}
+	return result
+}
+
+// enqueueScoring hands a saved response to the worker, which scores its sentiment and
+// then recalculates the respondent's churn risk. Anonymous responses are queued
+// without a user ID. If the task can't be queued the response stays pending until the
+// worker's periodic requeue picks it up.
+func enqueueScoring(response *models.SurveyResponse) {
+	var userID uint
+	if response.UserID != nil {
+		userID = *response.UserID
+	}
+
+	workerClient := getWorkerClient()
+	if workerClient == nil {
+		log.Printf("⚠️ Worker client is nil, response %d stays pending until requeued", response.ID)
+		return
+	}
+	if err := workerClient.EnqueueSurveyProcessing(response.ID, userID, ""); err != nil {
+		log.Printf("❌ Failed to enqueue sentiment scoring for response %d, it will be requeued: %v", response.ID, err)
+		return
+	}
+	log.Printf("📤 Sentiment scoring enqueued for response %d", response.ID)
+}
+
 // TODO: Replace with proper dependency injection
 func getWorkerClient() *worker.Client {
 	redisAddr := strings.TrimPrefix(config.AppConfig.RedisURL, "redis://")
@@ -150,15 +201,9 @@ func getWorkerClient() *worker.Client {
 }
 
 func GetSurveys(w http.ResponseWriter, r *http.Request) {
-	userID := r.Context().Value("userID").(uint)
-	userRepo := repository.NewUserRepository()
-	user, err := userRepo.GetByID(userID)
-	if err != nil {
-		utils.WriteError(w, http.StatusUnauthorized, "User not found")
-		return
-	}
+	user := middlewareAuth.CurrentUser(r)
 
-	surveyRepo := repository.NewSurveyRepository()
+	surveyRepo := repository.NewSurveyRepository().ForTenant(r.Context())
 	surveys, err := surveyRepo.GetByCompanyID(user.CompanyID)
 	if err != nil {
 		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch surveys")
@@ -175,7 +220,19 @@ func GetPublicSurvey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	surveyRepo := repository.NewSurveyRepository()
+	token, err := loadSurveyToken(r.URL.Query().Get("token"), uint(parseUint(surveyID)))
+	if err != nil {
+		writeSurveyTokenError(w, err)
+		return
+	}
+
+	ctx, err := tokenTenant(r.Context(), token)
+	if err != nil {
+		writeSurveyTokenError(w, err)
+		return
+	}
+
+	surveyRepo := repository.NewSurveyRepository().ForTenant(ctx)
 	survey, err := surveyRepo.GetByID(uint(parseUint(surveyID)))
 	if err != nil {
 		utils.WriteError(w, http.StatusNotFound, "Survey not found")
@@ -191,9 +248,10 @@ func GetPublicSurvey(w http.ResponseWriter, r *http.Request) {
 }
 
 type PublicSurveyResponseRequest struct {
-	SurveyID  uint     `json:"survey_id"`
-	UserToken uint     `json:"user_token"`
-	Responses []string `json:"responses"`
+	SurveyID  uint              `json:"survey_id"`
+	UserToken string            `json:"user_token"`
+	Answers   models.AnswerList `json:"answers"`
+	Responses []string          `json:"responses"`
 }
 
 func SubmitPublicResponse(w http.ResponseWriter, r *http.Request) {
@@ -203,54 +261,104 @@ func SubmitPublicResponse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get user from token (user ID)
-	userRepo := repository.NewUserRepository()
-	user, err := userRepo.GetByID(req.UserToken)
+	token, err := loadSurveyToken(req.UserToken, req.SurveyID)
 	if err != nil {
-		utils.WriteError(w, http.StatusUnauthorized, "Invalid user token")
+		writeSurveyTokenError(w, err)
 		return
 	}
 
-	// Get company language
-	companyRepo := repository.NewCompanyRepository()
-	company, _ := companyRepo.GetByID(user.CompanyID)
-	language := "en"
-	if company != nil && company.Language != "" {
-		language = company.Language
+	ctx, err := tokenTenant(r.Context(), token)
+	if err != nil {
+		writeSurveyTokenError(w, err)
+		return
 	}
 
-	// Combine responses for sentiment analysis
-	combinedText := strings.Join(req.Responses, " ")
-
-	// Analyze sentiment
-	mlService := sentiment.NewMLService(config.AppConfig.HuggingFaceToken)
-	sentimentScore, err := mlService.AnalyzeSentiment(combinedText, language)
+	surveyRepo := repository.NewSurveyRepository().ForTenant(ctx)
+	survey, err := surveyRepo.GetByID(req.SurveyID)
 	if err != nil {
-		log.Printf("⚠️ ML error: %v, using rule-based", err)
-		sentimentScore = sentiment.AnalyzeSentiment(combinedText, language)
+		utils.WriteError(w, http.StatusNotFound, "Survey not found")
+		return
+	}
+
+	answers := collectAnswers(req.Answers, req.Responses)
+	if err := survey.Questions.ValidateAnswers(answers); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get user the invitation was issued to
+	userRepo := repository.NewUserRepository().ForTenant(ctx)
+	if _, err := userRepo.GetByID(token.UserID); err != nil {
+		utils.WriteError(w, http.StatusUnauthorized, "Invalid user token")
+		return
 	}
 
-	// Save response
+	// Save response and burn the invitation token; the worker scores sentiment
 	response := &models.SurveyResponse{
-		SurveyID:  req.SurveyID,
-		UserID:    req.UserToken,
-		Responses: models.StringArray(req.Responses),
-		Sentiment: sentimentScore,
+		SurveyID:        req.SurveyID,
+		Responses:       answers.Strings(),
+		Answers:         answers,
+		SentimentStatus: models.SentimentPending,
+	}
+
+	var participation *models.SurveyParticipation
+	if survey.IsAnonymous {
+		participation = participationFor(survey, token.UserID)
+	} else {
+		response.UserID = &token.UserID
 	}
 
-	surveyRepo := repository.NewSurveyRepository()
-	if err := surveyRepo.CreateResponse(response); err != nil {
+	tokenRepo := repository.NewSurveyTokenRepository().ForTenant(ctx)
+	if err := tokenRepo.Redeem(token, response, participation); err != nil {
+		if errors.Is(err, models.ErrSurveyTokenUsed) || errors.Is(err, repository.ErrAlreadyParticipated) {
+			writeSurveyTokenError(w, models.ErrSurveyTokenUsed)
+			return
+		}
 		utils.WriteError(w, http.StatusInternalServerError, "Failed to save response")
 		return
 	}
+	recordResponse(ctx, survey, token.UserID, true)
+	enqueueScoring(response)
 
-	// Enqueue churn calculation
-	workerClient := getWorkerClient()
-	if workerClient != nil {
-		workerClient.EnqueueChurnCalculation(req.UserToken, user.CompanyID)
+	utils.WriteSuccess(w, map[string]string{"message": "Response submitted successfully"})
+}
+
+// loadSurveyToken resolves a raw invitation token and checks it may be used for surveyID
+func loadSurveyToken(rawToken string, surveyID uint) (*models.SurveyToken, error) {
+	if rawToken == "" {
+		return nil, gorm.ErrRecordNotFound
 	}
 
-	utils.WriteSuccess(w, map[string]string{"message": "Response submitted successfully"})
+	tokenRepo := repository.NewSurveyTokenRepository()
+	token, err := tokenRepo.GetByHash(utils.HashToken(rawToken))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := token.Validate(surveyID, time.Now()); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// tokenTenant scopes a public request to the company of the user the invitation was issued to
+func tokenTenant(ctx context.Context, token *models.SurveyToken) (context.Context, error) {
+	user, err := repository.NewUserRepository().GetByID(token.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return repository.WithTenant(ctx, user.CompanyID), nil
+}
+
+func writeSurveyTokenError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrSurveyTokenExpired):
+		utils.WriteError(w, http.StatusGone, "Survey link has expired")
+	case errors.Is(err, models.ErrSurveyTokenUsed):
+		utils.WriteError(w, http.StatusConflict, "Survey has already been submitted")
+	default:
+		utils.WriteError(w, http.StatusUnauthorized, "Invalid survey token")
+	}
 }
 
 func parseUint(s string) uint64 {
@@ -265,7 +373,7 @@ func GetSurveyResponses(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	surveyRepo := repository.NewSurveyRepository()
+	surveyRepo := repository.NewSurveyRepository().ForTenant(r.Context())
 	
 	// Get survey details
 	survey, err := surveyRepo.GetByID(uint(parseUint(surveyID)))
@@ -281,8 +389,13 @@ func GetSurveyResponses(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if survey.IsAnonymous {
+		writeAnonymousResponses(w, survey, responses)
+		return
+	}
+
 	// Enrich with user details
-	userRepo := repository.NewUserRepository()
+	userRepo := repository.NewUserRepository().ForTenant(r.Context())
 	type ResponseWithUser struct {
 		ID        uint     `json:"id"`
 		UserID    uint     `json:"user_id"`
@@ -290,19 +403,34 @@ func GetSurveyResponses(w http.ResponseWriter, r *http.Request) {
 			Name  string `json:"name"`
 			Email string `json:"email"`
 		} `json:"user"`
-		Responses []string `json:"responses"`
-		Sentiment float64  `json:"sentiment"`
-		CreatedAt string   `json:"created_at"`
+		Responses []string          `json:"responses"`
+		Answers   models.AnswerList `json:"answers"`
+		Sentiment float64           `json:"sentiment"`
+		SentimentStatus  string                   `json:"sentiment_status"`
+		Language         string                   `json:"language,omitempty"`
+		BurnoutIndex     *float64                 `json:"burnout_index,omitempty"`
+		AnswerSentiments []models.AnswerSentiment `json:"answer_sentiments"`
+		Aspects          []models.ResponseAspect  `json:"aspects"`
+		CreatedAt string            `json:"created_at"`
 	}
 
 	result := []ResponseWithUser{}
 	for _, resp := range responses {
-		user, _ := userRepo.GetByID(resp.UserID)
+		if resp.UserID == nil {
+			continue
+		}
+		user, _ := userRepo.GetByID(*resp.UserID)
 		responseData := ResponseWithUser{
 			ID:        resp.ID,
-			UserID:    resp.UserID,
+			UserID:    *resp.UserID,
 			Responses: resp.Responses,
+			Answers:   resp.Answers,
 			Sentiment: resp.Sentiment,
+			SentimentStatus:  resp.SentimentStatus,
+			Language:         resp.Language,
+			BurnoutIndex:     resp.BurnoutIndex,
+			AnswerSentiments: resp.AnswerSentiments,
+			Aspects:          resp.Aspects,
 			CreatedAt: resp.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		}
 		responseData.User.Name = user.Name
@@ -311,7 +439,80 @@ func GetSurveyResponses(w http.ResponseWriter, r *http.Request) {
 	}
 
 	utils.WriteSuccess(w, map[string]interface{}{
-		"survey":    survey,
-		"responses": result,
+		"survey":             survey,
+		"responses":          result,
+		"question_sentiment": services.SummarizeQuestionSentiment(survey.Questions, responses, 0),
+		"aspects":            services.SummarizeAspects(aspectsOf(responses)),
+	})
+}
+
+// writeAnonymousResponses returns answers without ids, users or timestamps, in random
+// order, and only once enough people have answered that no one can be singled out.
+// Per-answer sentiment is only given as question-level aggregates.
+func writeAnonymousResponses(w http.ResponseWriter, survey *models.Survey, responses []models.SurveyResponse) {
+	minGroupSize := services.MinGroupSize()
+	if len(responses) < minGroupSize {
+		utils.WriteError(w, http.StatusForbidden, fmt.Sprintf("Results are hidden until at least %d people have responded", minGroupSize))
+		return
+	}
+
+	type AnonymousResponse struct {
+		Responses []string          `json:"responses"`
+		Answers   models.AnswerList `json:"answers"`
+		Sentiment float64           `json:"sentiment"`
+	}
+
+	result := make([]AnonymousResponse, len(responses))
+	for i, resp := range responses {
+		result[i] = AnonymousResponse{
+			Responses: resp.Responses,
+			Answers:   resp.Answers,
+			Sentiment: resp.Sentiment,
+		}
+	}
+	rand.Shuffle(len(result), func(i, j int) { result[i], result[j] = result[j], result[i] })
+
+	utils.WriteSuccess(w, map[string]interface{}{
+		"survey":             survey,
+		"responses":          result,
+		"question_sentiment": services.SummarizeQuestionSentiment(survey.Questions, responses, minGroupSize),
+		"aspects":            services.SummarizeAspects(aspectsOf(responses)),
 	})
-}
\ No newline at end of file
+}
+
+func aspectsOf(responses []models.SurveyResponse) []models.ResponseAspect {
+	aspects := []models.ResponseAspect{}
+	for _, resp := range responses {
+		aspects = append(aspects, resp.Aspects...)
+	}
+	return aspects
+}
+
+// GetSurveySummary - Per-question aggregates, with small groups suppressed for anonymous surveys
+func GetSurveySummary(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "surveyID"), 10, 32)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid survey ID")
+		return
+	}
+
+	surveyRepo := repository.NewSurveyRepository().ForTenant(r.Context())
+	survey, err := surveyRepo.GetByID(uint(id))
+	if err != nil {
+		utils.WriteError(w, http.StatusNotFound, "Survey not found")
+		return
+	}
+
+	responses, err := surveyRepo.GetResponsesBySurveyID(survey.ID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch responses")
+		return
+	}
+
+	minGroupSize := 0
+	if survey.IsAnonymous {
+		minGroupSize = services.MinGroupSize()
+	}
+
+	utils.WriteSuccess(w, services.SummarizeSurvey(survey, responses, minGroupSize))
+}
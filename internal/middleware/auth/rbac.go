@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+	"github.com/VinVorteX/NoBurn/internal/policy"
+	"github.com/VinVorteX/NoBurn/internal/repository"
+	"github.com/VinVorteX/NoBurn/internal/utils"
+)
+
+// LoadUser resolves the authenticated user once per request so handlers and
+// permission checks can read it with CurrentUser, and scopes tenant repositories
+// to the user's company. It must run after RequireAuth.
+func LoadUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := r.Context().Value("userID").(uint)
+		if !ok {
+			utils.WriteError(w, http.StatusUnauthorized, "Missing or invalid token")
+			return
+		}
+
+		user, err := repository.NewUserRepository().GetByID(userID)
+		if err != nil {
+			utils.WriteError(w, http.StatusUnauthorized, "User not found")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), "user", user)
+		ctx = repository.WithTenant(ctx, user.CompanyID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequirePermission rejects requests from users whose role may not perform action
+func RequirePermission(action policy.Action) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := CurrentUser(r)
+			if user == nil || !policy.Can(user.Role, action) {
+				utils.WriteError(w, http.StatusForbidden, "You do not have permission to perform this action")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// CurrentUser returns the user loaded by LoadUser, or nil outside of it
+func CurrentUser(r *http.Request) *models.User {
+	user, _ := r.Context().Value("user").(*models.User)
+	return user
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// UserActivity tracks the engagement signals the churn model uses. Only responses
+// that redeemed an invitation count towards InvitationsAnswered. Responses to
+// anonymous surveys leave LastResponseAt and UpdatedAt untouched, so neither can be
+// matched against anonymous answers.
+type UserActivity struct {
+	UserID              uint       `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	LastLoginAt         *time.Time `json:"last_login_at,omitempty"`
+	LastResponseAt      *time.Time `json:"last_response_at,omitempty"`
+	InvitationsSent     int        `json:"invitations_sent" gorm:"default:0"`
+	InvitationsAnswered int        `json:"invitations_answered" gorm:"default:0"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// ResponseRate is the share of survey invitations answered. Employees who have not
+// been invited yet count as fully responsive rather than disengaged.
+func (a *UserActivity) ResponseRate() float64 {
+	if a.InvitationsSent <= 0 {
+		return 1.0
+	}
+	return math.Min(float64(a.InvitationsAnswered)/float64(a.InvitationsSent), 1.0)
+}
+
+// LastActiveAt is the most recent login or survey response, or nil if there is neither
+func (a *UserActivity) LastActiveAt() *time.Time {
+	if a.LastLoginAt == nil {
+		return a.LastResponseAt
+	}
+	if a.LastResponseAt == nil || a.LastLoginAt.After(*a.LastResponseAt) {
+		return a.LastLoginAt
+	}
+	return a.LastResponseAt
+}
+
+// DaysSince returns whole days from t to now, or 0 when t is unknown
+func DaysSince(t *time.Time, now time.Time) int {
+	if t == nil || now.Before(*t) {
+		return 0
+	}
+	return int(now.Sub(*t).Hours() / 24)
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	AlertRuleThreshold      = "threshold"       // risk at or above Threshold
+	AlertRuleRiskIncrease   = "risk_increase"   // risk rose by at least Threshold within WindowDays
+	AlertRuleNegativeStreak = "negative_streak" // the last Count survey responses were all negative
+)
+
+// AlertRule is a condition checked after every risk calculation. A rule alerts about
+// an employee at most once per CooldownDays.
+type AlertRule struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	CompanyID    uint      `json:"company_id" gorm:"index"`
+	Name         string    `json:"name" gorm:"not null"`
+	Type         string    `json:"type" gorm:"not null"`
+	Threshold    float64   `json:"threshold"`
+	WindowDays   int       `json:"window_days"`
+	Count        int       `json:"count"`
+	CooldownDays int       `json:"cooldown_days"`
+	IsActive     bool      `json:"is_active"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (r *AlertRule) Validate() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	if r.CooldownDays < 0 {
+		return errors.New("cooldown_days cannot be negative")
+	}
+	switch r.Type {
+	case AlertRuleThreshold:
+		if r.Threshold <= 0 || r.Threshold > 1 {
+			return errors.New("threshold must be between 0 and 1")
+		}
+	case AlertRuleRiskIncrease:
+		if r.Threshold <= 0 || r.Threshold > 1 {
+			return errors.New("threshold must be between 0 and 1")
+		}
+		if r.WindowDays < 1 {
+			return errors.New("window_days must be at least 1")
+		}
+	case AlertRuleNegativeStreak:
+		if r.Count < 1 {
+			return errors.New("count must be at least 1")
+		}
+	default:
+		return errors.New("type must be threshold, risk_increase or negative_streak")
+	}
+	return nil
+}
+
+// DefaultAlertRules apply to companies that have not configured any rules. The
+// threshold rule follows the company's high-risk threshold.
+func DefaultAlertRules(companyID uint, highRiskThreshold float64) []AlertRule {
+	return []AlertRule{
+		{CompanyID: companyID, Name: "High churn risk", Type: AlertRuleThreshold, Threshold: highRiskThreshold, CooldownDays: 14, IsActive: true},
+		{CompanyID: companyID, Name: "Sharp risk increase", Type: AlertRuleRiskIncrease, Threshold: 0.3, WindowDays: 7, CooldownDays: 7, IsActive: true},
+		{CompanyID: companyID, Name: "Repeated negative feedback", Type: AlertRuleNegativeStreak, Count: 3, CooldownDays: 14, IsActive: true},
+	}
+}
+
+// Alert records that a rule fired for an employee, for deduplication and history.
+// Default rules have no ID, so their alerts are matched by RuleType instead.
+type Alert struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	CompanyID uint      `json:"company_id" gorm:"index"`
+	UserID    uint      `json:"user_id" gorm:"index"`
+	User      *User     `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	RuleID    *uint     `json:"rule_id,omitempty"`
+	RuleType  string    `json:"rule_type"`
+	RuleName  string    `json:"rule_name"`
+	RiskScore float64   `json:"risk_score"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
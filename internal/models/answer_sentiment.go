@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+const (
+	SentimentPositive = "positive"
+	SentimentNeutral  = "neutral"
+	SentimentNegative = "negative"
+)
+
+// SourceScale marks sentiment read straight off a numeric answer rather than from a provider
+const SourceScale = "scale"
+
+// AnswerSentiment is the sentiment of one answer in a survey response. SurveyID is
+// copied from the response so question-level aggregates don't need a join.
+type AnswerSentiment struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	ResponseID    uint      `json:"response_id" gorm:"uniqueIndex:idx_answer_sentiments_answer;not null"`
+	SurveyID      uint      `json:"survey_id" gorm:"index;not null"`
+	QuestionIndex int       `json:"question_index" gorm:"uniqueIndex:idx_answer_sentiments_answer"`
+	Sentiment     float64   `json:"sentiment"` // -1 to 1
+	Label         string    `json:"label"`
+	Confidence    float64   `json:"confidence"` // 0 to 1
+	Source        string    `json:"source"`     // "scale" or the sentiment provider that answered
+
+	// Burnout dimensions from 0 to 1, for free-text answers only. Signals lists the
+	// dimensions strong enough to tag the answer with.
+	Exhaustion    float64     `json:"exhaustion"`
+	Stress        float64     `json:"stress"`
+	Cynicism      float64     `json:"cynicism"`
+	Disengagement float64     `json:"disengagement"`
+	Signals       StringArray `json:"signals,omitempty" gorm:"type:jsonb"`
+
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// SentimentLabel buckets a score; scores within 0.1 of zero are neutral
+func SentimentLabel(score float64) string {
+	switch {
+	case score > 0.1:
+		return SentimentPositive
+	case score < -0.1:
+		return SentimentNegative
+	}
+	return SentimentNeutral
+}
+
+// AverageSentiment is the response-level score: every scored answer counts equally,
+// so one strongly negative answer isn't drowned out by a long neutral one
+func AverageSentiment(answers []AnswerSentiment) float64 {
+	if len(answers) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, answer := range answers {
+		total += answer.Sentiment
+	}
+	return total / float64(len(answers))
+}
+
+// ResponseAspect is the sentiment of one workplace aspect, such as compensation or
+// manager, as mentioned in one answer of a survey response
+type ResponseAspect struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	ResponseID    uint      `json:"response_id" gorm:"uniqueIndex:idx_response_aspects_answer;not null"`
+	SurveyID      uint      `json:"survey_id" gorm:"index;not null"`
+	QuestionIndex int       `json:"question_index" gorm:"uniqueIndex:idx_response_aspects_answer"`
+	Aspect        string    `json:"aspect" gorm:"uniqueIndex:idx_response_aspects_answer;not null"`
+	Sentiment     float64   `json:"sentiment"` // -1 to 1
+	Mentions      int       `json:"mentions"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ChurnFeatureSnapshot keeps the inputs of a risk calculation so past scores can be
+// explained and used as training data once the outcome is known
+type ChurnFeatureSnapshot struct {
+	AvgSentiment      float64 `json:"avg_sentiment"`
+	ResponseRate      float64 `json:"response_rate"`
+	DaysInactive      int     `json:"days_inactive"`
+	NegativeResponses int     `json:"negative_responses"`
+	TotalResponses    int     `json:"total_responses"`
+	LastLoginDays     int     `json:"last_login_days"`
+	BurnoutIndex      float64 `json:"burnout_index"`
+}
+
+func (f *ChurnFeatureSnapshot) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, f)
+}
+
+func (f ChurnFeatureSnapshot) Value() (driver.Value, error) {
+	return json.Marshal(f)
+}
+
+// FeatureContribution is how much one feature contributed to a risk score. Share is
+// the signed fraction of the score's total absolute impact, e.g. 0.62 for "sentiment
+// contributed 62% of this score".
+type FeatureContribution struct {
+	Feature string  `json:"feature"`
+	Label   string  `json:"label"`
+	Value   float64 `json:"value"` // normalized 0-1, higher is riskier
+	Impact  float64 `json:"impact"`
+	Share   float64 `json:"share"`
+}
+
+type ContributionList []FeatureContribution
+
+func (l *ContributionList) Scan(value interface{}) error {
+	if value == nil {
+		*l = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, l)
+}
+
+func (l ContributionList) Value() (driver.Value, error) {
+	return json.Marshal(l)
+}
+
+type Float64Array []float64
+
+func (a *Float64Array) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, a)
+}
+
+func (a Float64Array) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
+// ChurnModel is one trained version of a company's churn model. Only the latest
+// active version is used for predictions; older versions are kept for reference.
+type ChurnModel struct {
+	ID        uint         `json:"id" gorm:"primaryKey"`
+	CompanyID uint         `json:"company_id" gorm:"index"`
+	Version   int          `json:"version"`
+	Features  StringArray  `json:"features" gorm:"type:jsonb"` // names of the weights, in order
+	Weights   Float64Array `json:"weights" gorm:"type:jsonb"`
+	Bias      float64      `json:"bias"`
+	Samples   int          `json:"samples"`
+	Leavers   int          `json:"leavers"`
+	Accuracy  float64      `json:"accuracy"` // on the training set
+	IsActive  bool         `json:"is_active" gorm:"default:true"`
+	CreatedAt time.Time    `json:"created_at"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+const (
+	ExitVoluntary   = "voluntary"
+	ExitInvoluntary = "involuntary"
+)
+
+func IsValidExitType(exitType string) bool {
+	return exitType == ExitVoluntary || exitType == ExitInvoluntary
+}
+
+// EmployeeExit records that an employee left. The user is soft-deleted when the exit is
+// recorded, so their responses and risk history stay in place for reporting and training.
+type EmployeeExit struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	UserID     uint      `json:"user_id" gorm:"uniqueIndex"`
+	User       *User     `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	CompanyID  uint      `json:"company_id" gorm:"index"`
+	ManagerID  *uint     `json:"manager_id,omitempty"` // manager at the time of exit
+	ExitDate   time.Time `json:"exit_date"`
+	ExitType   string    `json:"exit_type"`
+	Reason     string    `json:"reason"`
+	Regretted  bool      `json:"regretted"`
+	RecordedBy uint      `json:"recorded_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestStringArrayScan(t *testing.T) {
@@ -53,4 +54,385 @@ func TestStringArrayScanNil(t *testing.T) {
 	if arr != nil {
 		t.Error("Expected nil array")
 	}
-}
\ No newline at end of file
+}
+func TestSurveyTokenValidate(t *testing.T) {
+	now := time.Now()
+	used := now.Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		token    SurveyToken
+		surveyID uint
+		want     error
+	}{
+		{"valid", SurveyToken{SurveyID: 1, ExpiresAt: now.Add(time.Hour)}, 1, nil},
+		{"wrong survey", SurveyToken{SurveyID: 2, ExpiresAt: now.Add(time.Hour)}, 1, ErrSurveyTokenMismatch},
+		{"expired", SurveyToken{SurveyID: 1, ExpiresAt: now.Add(-time.Minute)}, 1, ErrSurveyTokenExpired},
+		{"used", SurveyToken{SurveyID: 1, ExpiresAt: now.Add(time.Hour), UsedAt: &used}, 1, ErrSurveyTokenUsed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.token.Validate(tt.surveyID, now); err != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestQuestionListScanLegacyStrings(t *testing.T) {
+	var questions QuestionList
+
+	if err := questions.Scan([]byte(`["How are you?", {"text": "Rate us", "type": "nps", "required": true}]`)); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if len(questions) != 2 {
+		t.Fatalf("Expected 2 questions, got %d", len(questions))
+	}
+
+	if questions[0].Type != QuestionTypeText || !questions[0].Required {
+		t.Errorf("Expected legacy question to be required text, got %+v", questions[0])
+	}
+
+	if questions[1].Type != QuestionTypeNPS {
+		t.Errorf("Expected nps question, got %s", questions[1].Type)
+	}
+}
+
+func TestQuestionListValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		questions QuestionList
+		wantErr   bool
+	}{
+		{"empty", QuestionList{}, true},
+		{"text", QuestionList{{Text: "Why?", Type: QuestionTypeText}}, false},
+		{"unknown type", QuestionList{{Text: "Why?", Type: "slider"}}, true},
+		{"choice without options", QuestionList{{Text: "Pick", Type: QuestionTypeSingleChoice}}, true},
+		{"choice", QuestionList{{Text: "Pick", Type: QuestionTypeMultiChoice, Options: []string{"a", "b"}}}, false},
+		{"likert with options", QuestionList{{Text: "Rate", Type: QuestionTypeLikert, Options: []string{"a"}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.questions.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateAnswers(t *testing.T) {
+	questions := QuestionList{
+		{Text: "Rate", Type: QuestionTypeLikert, Required: true},
+		{Text: "Recommend?", Type: QuestionTypeNPS, Required: true},
+		{Text: "Pick", Type: QuestionTypeSingleChoice, Options: []string{"a", "b"}, Required: true},
+		{Text: "Comments", Type: QuestionTypeText},
+	}
+
+	var answers AnswerList
+	if err := json.Unmarshal([]byte(`["4", 9, "b", ""]`), &answers); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if err := questions.ValidateAnswers(answers); err != nil {
+		t.Fatalf("Expected answers to be valid, got %v", err)
+	}
+
+	if answers[0].Value == nil || *answers[0].Value != 4 {
+		t.Errorf("Expected likert text answer to be normalized to 4, got %+v", answers[0])
+	}
+
+	invalid := []AnswerList{
+		{{Text: "6"}, {Text: "9"}, {Text: "a"}, {}},
+		{{Text: "3"}, {Text: "11"}, {Text: "a"}, {}},
+		{{Text: "3"}, {Text: "9"}, {Text: "c"}, {}},
+		{{}, {Text: "9"}, {Text: "a"}, {}},
+		{{Text: "3"}},
+	}
+	for i, a := range invalid {
+		if err := questions.ValidateAnswers(a); err == nil {
+			t.Errorf("Expected answers %d to be invalid", i)
+		}
+	}
+}
+
+func TestSentimentPerAnswer(t *testing.T) {
+	questions := QuestionList{
+		{Text: "Rate", Type: QuestionTypeLikert},
+		{Text: "Recommend?", Type: QuestionTypeNPS},
+		{Text: "Pick", Type: QuestionTypeSingleChoice, Options: []string{"a", "b"}},
+		{Text: "Comments", Type: QuestionTypeText},
+		{Text: "Manager", Type: QuestionTypeText},
+	}
+	five, ten := 5.0, 10.0
+	answers := AnswerList{{Value: &five}, {Value: &ten}, {Choices: []string{"a"}}, {Text: "meh"}, {Text: "awful"}}
+
+	analyzed := []string{}
+	sentiments := questions.SentimentPerAnswer(answers, func(text string) (float64, float64, string) {
+		analyzed = append(analyzed, text)
+		if text == "awful" {
+			return -1, 0.9, "fake"
+		}
+		return 0, 0.6, "fake"
+	})
+
+	if len(analyzed) != 2 || analyzed[0] != "meh" || analyzed[1] != "awful" {
+		t.Errorf("Expected each free-text answer to be analyzed separately, got %v", analyzed)
+	}
+	if len(sentiments) != 4 {
+		t.Fatalf("Expected 4 scored answers without the choice, got %+v", sentiments)
+	}
+	if s := sentiments[0]; s.QuestionIndex != 0 || s.Source != SourceScale || s.Label != SentimentPositive || s.Confidence != 1 {
+		t.Errorf("Expected a positive scale answer, got %+v", s)
+	}
+	if s := sentiments[3]; s.QuestionIndex != 4 || s.Label != SentimentNegative || s.Confidence != 0.9 || s.Source != "fake" {
+		t.Errorf("Expected the negative answer with its own confidence, got %+v", s)
+	}
+
+	// (1 + 1 + 0 - 1) / 4
+	if score := AverageSentiment(sentiments); score != 0.25 {
+		t.Errorf("Expected score 0.25, got %f", score)
+	}
+}
+
+func TestFreeText(t *testing.T) {
+	questions := QuestionList{
+		{Text: "Rate", Type: QuestionTypeLikert},
+		{Text: "Comments", Type: QuestionTypeText},
+		{Text: "Manager", Type: QuestionTypeText},
+		{Text: "Anything else?", Type: QuestionTypeText},
+	}
+	four := 4.0
+	answers := AnswerList{{Value: &four}, {Text: "kaam zyada hai"}, {Text: "  "}, {Text: "manager accha hai"}}
+
+	if got := questions.FreeText(answers); got != "kaam zyada hai\nmanager accha hai" {
+		t.Errorf("Expected only the non-empty text answers, got %q", got)
+	}
+}
+
+func TestSentimentLabel(t *testing.T) {
+	tests := []struct {
+		score float64
+		want  string
+	}{
+		{0.5, SentimentPositive},
+		{0.1, SentimentNeutral},
+		{0, SentimentNeutral},
+		{-0.1, SentimentNeutral},
+		{-0.11, SentimentNegative},
+	}
+	for _, tt := range tests {
+		if got := SentimentLabel(tt.score); got != tt.want {
+			t.Errorf("Expected %s for %f, got %s", tt.want, tt.score, got)
+		}
+	}
+}
+
+func TestSurveyScheduleSpec(t *testing.T) {
+	weekly := SurveySchedule{IntervalWeeks: 1, Weekday: 1, Hour: 9, Minute: 30}
+
+	spec, err := weekly.Spec("Asia/Kolkata")
+	if err != nil {
+		t.Fatalf("Spec failed: %v", err)
+	}
+	if spec != "CRON_TZ=Asia/Kolkata 30 9 * * 1" {
+		t.Errorf("Unexpected spec %s", spec)
+	}
+
+	monthly := SurveySchedule{Cronspec: "0 10 1 * *", Timezone: "Europe/London"}
+	spec, err = monthly.Spec("Asia/Kolkata")
+	if err != nil {
+		t.Fatalf("Spec failed: %v", err)
+	}
+	if spec != "CRON_TZ=Europe/London 0 10 1 * *" {
+		t.Errorf("Expected schedule timezone to override company timezone, got %s", spec)
+	}
+
+	if _, err := weekly.Spec("Not/AZone"); err == nil {
+		t.Error("Expected invalid timezone to fail")
+	}
+}
+
+func TestSurveyScheduleValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule SurveySchedule
+		wantErr  bool
+	}{
+		{"weekly", SurveySchedule{IntervalWeeks: 1, Weekday: 5, Hour: 17}, false},
+		{"bad cron", SurveySchedule{Cronspec: "every monday"}, true},
+		{"cron with tz", SurveySchedule{Cronspec: "CRON_TZ=UTC 0 9 * * 1"}, true},
+		{"bad weekday", SurveySchedule{IntervalWeeks: 1, Weekday: 7}, true},
+		{"zero interval", SurveySchedule{Weekday: 1}, true},
+		{"bad timezone", SurveySchedule{IntervalWeeks: 2, Timezone: "Mars/Base"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schedule.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSurveyScheduleDue(t *testing.T) {
+	now := time.Now()
+	oneWeekAgo := now.Add(-7 * 24 * time.Hour)
+	twoWeeksAgo := now.Add(-14*24*time.Hour + time.Hour)
+
+	if !(&SurveySchedule{IntervalWeeks: 2}).Due(now, "UTC") {
+		t.Error("Expected first run to be due")
+	}
+	if (&SurveySchedule{IntervalWeeks: 2, LastRunAt: &oneWeekAgo}).Due(now, "UTC") {
+		t.Error("Expected fortnightly schedule to skip the in-between week")
+	}
+	if !(&SurveySchedule{IntervalWeeks: 2, LastRunAt: &twoWeeksAgo}).Due(now, "UTC") {
+		t.Error("Expected fortnightly schedule to run after two weeks")
+	}
+}
+
+func TestSurveyScheduleDueOncePerTrigger(t *testing.T) {
+	// Monday 9:30 UTC, when a Monday 9:00 schedule fired
+	trigger := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	now := trigger.Add(30 * time.Minute)
+	ranAtTrigger := trigger.Add(5 * time.Second)
+	ranLastWeek := trigger.Add(-7*24*time.Hour + 5*time.Second)
+
+	tests := []struct {
+		name      string
+		schedule  SurveySchedule
+		lastRunAt time.Time
+		want      bool
+	}{
+		{"weekly, new trigger", SurveySchedule{IntervalWeeks: 1, Weekday: 1, Hour: 9}, ranLastWeek, true},
+		{"weekly, retried", SurveySchedule{IntervalWeeks: 1, Weekday: 1, Hour: 9}, ranAtTrigger, false},
+		{"cron, new trigger", SurveySchedule{Cronspec: "0 9 * * 1"}, ranLastWeek, true},
+		{"cron, retried", SurveySchedule{Cronspec: "0 9 * * 1"}, ranAtTrigger, false},
+		{"fortnightly, retried", SurveySchedule{IntervalWeeks: 2, Weekday: 1, Hour: 9}, ranAtTrigger, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.schedule.LastRunAt = &tt.lastRunAt
+			if got := tt.schedule.Due(now, "UTC"); got != tt.want {
+				t.Errorf("Expected Due %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUserActivityResponseRate(t *testing.T) {
+	tests := []struct {
+		sent, answered int
+		expected       float64
+	}{
+		{0, 0, 1.0},
+		{4, 1, 0.25},
+		{2, 2, 1.0},
+		{1, 3, 1.0},
+	}
+
+	for _, tt := range tests {
+		a := UserActivity{InvitationsSent: tt.sent, InvitationsAnswered: tt.answered}
+		if rate := a.ResponseRate(); rate != tt.expected {
+			t.Errorf("Expected rate %f for %d/%d, got %f", tt.expected, tt.answered, tt.sent, rate)
+		}
+	}
+}
+
+func TestUserActivityLastActiveAt(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-48 * time.Hour)
+
+	a := UserActivity{}
+	if a.LastActiveAt() != nil {
+		t.Errorf("Expected no activity")
+	}
+
+	a = UserActivity{LastLoginAt: &earlier, LastResponseAt: &now}
+	if !a.LastActiveAt().Equal(now) {
+		t.Errorf("Expected the later response time")
+	}
+	if days := DaysSince(a.LastLoginAt, now); days != 2 {
+		t.Errorf("Expected 2 days since login, got %d", days)
+	}
+	if days := DaysSince(nil, now); days != 0 {
+		t.Errorf("Expected 0 days for unknown time, got %d", days)
+	}
+}
+
+func TestAlertRuleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    AlertRule
+		wantErr bool
+	}{
+		{"threshold", AlertRule{Name: "High", Type: AlertRuleThreshold, Threshold: 0.7}, false},
+		{"increase", AlertRule{Name: "Jump", Type: AlertRuleRiskIncrease, Threshold: 0.3, WindowDays: 7}, false},
+		{"streak", AlertRule{Name: "Negative", Type: AlertRuleNegativeStreak, Count: 3}, false},
+		{"missing name", AlertRule{Type: AlertRuleThreshold, Threshold: 0.7}, true},
+		{"threshold out of range", AlertRule{Name: "High", Type: AlertRuleThreshold, Threshold: 1.5}, true},
+		{"increase without window", AlertRule{Name: "Jump", Type: AlertRuleRiskIncrease, Threshold: 0.3}, true},
+		{"empty streak", AlertRule{Name: "Negative", Type: AlertRuleNegativeStreak}, true},
+		{"unknown type", AlertRule{Name: "Other", Type: "sentiment"}, true},
+		{"negative cooldown", AlertRule{Name: "High", Type: AlertRuleThreshold, Threshold: 0.7, CooldownDays: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRiskSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(s *RiskSettings)
+		wantErr bool
+	}{
+		{"defaults", func(s *RiskSettings) {}, false},
+		{"reweighted", func(s *RiskSettings) { s.SentimentWeight, s.ActivityWeight = 0.3, 0.25 }, false},
+		{"burnout weight off", func(s *RiskSettings) { s.SentimentWeight, s.BurnoutWeight = 0.55, 0 }, false},
+		{"weights not summing to 1", func(s *RiskSettings) { s.SentimentWeight = 0.8 }, true},
+		{"negative weight", func(s *RiskSettings) { s.SentimentWeight, s.ResponseWeight = 0.8, -0.15 }, true},
+		{"threshold above 1", func(s *RiskSettings) { s.HighRiskThreshold = 1.2 }, true},
+		{"watch above high", func(s *RiskSettings) { s.WatchThreshold = 0.8 }, true},
+		{"sentiment cutoff out of range", func(s *RiskSettings) { s.LowSentimentCutoff = -2 }, true},
+		{"burnout cutoff out of range", func(s *RiskSettings) { s.HighBurnoutCutoff = 1.5 }, true},
+		{"negative days", func(s *RiskSettings) { s.InactiveDaysCutoff = -1 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := DefaultRiskSettings(1)
+			tt.modify(settings)
+			err := settings.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestScoredResponses(t *testing.T) {
+	responses := []SurveyResponse{
+		{ID: 1, SentimentStatus: SentimentScored},
+		{ID: 2, SentimentStatus: SentimentPending},
+		{ID: 3},
+		{ID: 4, SentimentStatus: SentimentFailed},
+	}
+
+	scored := ScoredResponses(responses)
+	if len(scored) != 2 || scored[0].ID != 1 || scored[1].ID != 3 {
+		t.Errorf("Expected responses 1 and 3, got %+v", scored)
+	}
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Department struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	CompanyID uint           `json:"company_id" gorm:"index"`
+	Name      string         `json:"name" gorm:"not null"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+type Team struct {
+	ID           uint           `json:"id" gorm:"primaryKey"`
+	CompanyID    uint           `json:"company_id" gorm:"index"`
+	DepartmentID *uint          `json:"department_id,omitempty" gorm:"index"`
+	Department   *Department    `json:"department,omitempty" gorm:"foreignKey:DepartmentID"`
+	Name         string         `json:"name" gorm:"not null"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+}
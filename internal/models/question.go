@@ -0,0 +1,323 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	QuestionTypeText         = "text"
+	QuestionTypeLikert       = "likert" // 1 to 5
+	QuestionTypeNPS          = "nps"    // 0 to 10
+	QuestionTypeSingleChoice = "single_choice"
+	QuestionTypeMultiChoice  = "multi_choice"
+)
+
+type Question struct {
+	Text     string   `json:"text"`
+	Type     string   `json:"type"`
+	Options  []string `json:"options,omitempty"`
+	Required bool     `json:"required"`
+}
+
+// UnmarshalJSON also accepts a bare string, which is how questions were stored
+// before typed questions existed. Such questions are required free text.
+func (q *Question) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*q = Question{Text: text, Type: QuestionTypeText, Required: true}
+		return nil
+	}
+
+	type plain Question
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	if p.Type == "" {
+		p.Type = QuestionTypeText
+	}
+	*q = Question(p)
+	return nil
+}
+
+func (q Question) IsNumeric() bool {
+	return q.Type == QuestionTypeLikert || q.Type == QuestionTypeNPS
+}
+
+func (q Question) Validate() error {
+	if strings.TrimSpace(q.Text) == "" {
+		return errors.New("question text is required")
+	}
+
+	switch q.Type {
+	case QuestionTypeText, QuestionTypeLikert, QuestionTypeNPS:
+		if len(q.Options) > 0 {
+			return fmt.Errorf("%s questions do not take options", q.Type)
+		}
+	case QuestionTypeSingleChoice, QuestionTypeMultiChoice:
+		if len(q.Options) < 2 {
+			return fmt.Errorf("%s questions need at least 2 options", q.Type)
+		}
+		seen := make(map[string]bool)
+		for _, opt := range q.Options {
+			if strings.TrimSpace(opt) == "" || seen[opt] {
+				return errors.New("choice options must be unique and non-empty")
+			}
+			seen[opt] = true
+		}
+	default:
+		return fmt.Errorf("unknown question type %q", q.Type)
+	}
+	return nil
+}
+
+// ValidateAnswer checks an answer against the question type. Empty answers are
+// only accepted for optional questions.
+func (q Question) ValidateAnswer(a Answer) error {
+	if a.IsEmpty() {
+		if q.Required {
+			return errors.New("answer is required")
+		}
+		return nil
+	}
+
+	switch q.Type {
+	case QuestionTypeText:
+		if a.Value != nil || len(a.Choices) > 0 {
+			return errors.New("expected a text answer")
+		}
+	case QuestionTypeLikert, QuestionTypeNPS:
+		min, max := q.scale()
+		if a.Value == nil {
+			return errors.New("expected a numeric answer")
+		}
+		v := *a.Value
+		if v != math.Trunc(v) || v < min || v > max {
+			return fmt.Errorf("answer must be a whole number from %g to %g", min, max)
+		}
+	case QuestionTypeSingleChoice, QuestionTypeMultiChoice:
+		if q.Type == QuestionTypeSingleChoice && len(a.Choices) != 1 {
+			return errors.New("expected exactly one choice")
+		}
+		for _, choice := range a.Choices {
+			if !q.hasOption(choice) {
+				return fmt.Errorf("%q is not a valid option", choice)
+			}
+		}
+	}
+	return nil
+}
+
+// Score maps numeric answers onto the -1 to 1 sentiment scale so they can be
+// combined with text sentiment. ok is false for answers that are not scored directly.
+func (q Question) Score(a Answer) (score float64, ok bool) {
+	if !q.IsNumeric() || a.Value == nil {
+		return 0, false
+	}
+	min, max := q.scale()
+	mid := (min + max) / 2
+	return (*a.Value - mid) / (max - mid), true
+}
+
+func (q Question) scale() (float64, float64) {
+	if q.Type == QuestionTypeNPS {
+		return 0, 10
+	}
+	return 1, 5
+}
+
+func (q Question) hasOption(option string) bool {
+	for _, opt := range q.Options {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+type QuestionList []Question
+
+func (ql *QuestionList) Scan(value interface{}) error {
+	if value == nil {
+		*ql = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, ql)
+}
+
+func (ql QuestionList) Value() (driver.Value, error) {
+	return json.Marshal(ql)
+}
+
+func (ql QuestionList) Validate() error {
+	if len(ql) == 0 {
+		return errors.New("survey needs at least one question")
+	}
+	for i, q := range ql {
+		if err := q.Validate(); err != nil {
+			return fmt.Errorf("question %d: %v", i+1, err)
+		}
+	}
+	return nil
+}
+
+// ValidateAnswers normalizes answers in place (see Answer.Normalize) and checks
+// there is exactly one valid answer per question.
+func (ql QuestionList) ValidateAnswers(answers AnswerList) error {
+	if len(answers) != len(ql) {
+		return fmt.Errorf("expected %d answers, got %d", len(ql), len(answers))
+	}
+	for i, q := range ql {
+		answers[i] = answers[i].Normalize(q)
+		if err := q.ValidateAnswer(answers[i]); err != nil {
+			return fmt.Errorf("question %d: %v", i+1, err)
+		}
+	}
+	return nil
+}
+
+type Answer struct {
+	Text    string   `json:"text,omitempty"`
+	Value   *float64 `json:"value,omitempty"`
+	Choices []string `json:"choices,omitempty"`
+}
+
+// UnmarshalJSON accepts the shorthand forms "text", 4 and ["a", "b"] as well as the full object
+func (a *Answer) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*a = Answer{Text: text}
+		return nil
+	}
+
+	var value float64
+	if err := json.Unmarshal(data, &value); err == nil {
+		*a = Answer{Value: &value}
+		return nil
+	}
+
+	var choices []string
+	if err := json.Unmarshal(data, &choices); err == nil {
+		*a = Answer{Choices: choices}
+		return nil
+	}
+
+	type plain Answer
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*a = Answer(p)
+	return nil
+}
+
+func (a Answer) IsEmpty() bool {
+	return strings.TrimSpace(a.Text) == "" && a.Value == nil && len(a.Choices) == 0
+}
+
+// Normalize converts a plain text answer into the shape the question expects,
+// so clients that only send strings keep working with typed questions.
+func (a Answer) Normalize(q Question) Answer {
+	if a.Value != nil || len(a.Choices) > 0 || strings.TrimSpace(a.Text) == "" {
+		return a
+	}
+
+	text := strings.TrimSpace(a.Text)
+	switch q.Type {
+	case QuestionTypeLikert, QuestionTypeNPS:
+		if v, err := strconv.ParseFloat(text, 64); err == nil {
+			return Answer{Value: &v}
+		}
+	case QuestionTypeSingleChoice:
+		return Answer{Choices: []string{text}}
+	case QuestionTypeMultiChoice:
+		choices := []string{}
+		for _, c := range strings.Split(text, ",") {
+			if c = strings.TrimSpace(c); c != "" {
+				choices = append(choices, c)
+			}
+		}
+		return Answer{Choices: choices}
+	}
+	return a
+}
+
+// String renders the answer for display and for the legacy responses column
+func (a Answer) String() string {
+	switch {
+	case a.Value != nil:
+		return strconv.FormatFloat(*a.Value, 'f', -1, 64)
+	case len(a.Choices) > 0:
+		return strings.Join(a.Choices, ", ")
+	default:
+		return a.Text
+	}
+}
+
+type AnswerList []Answer
+
+func (al *AnswerList) Scan(value interface{}) error {
+	if value == nil {
+		*al = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, al)
+}
+
+func (al AnswerList) Value() (driver.Value, error) {
+	return json.Marshal(al)
+}
+
+func (al AnswerList) Strings() StringArray {
+	result := make(StringArray, len(al))
+	for i, a := range al {
+		result[i] = a.String()
+	}
+	return result
+}
+
+// FreeText joins the non-empty answers to text questions
+func (ql QuestionList) FreeText(answers AnswerList) string {
+	texts := []string{}
+	for i, q := range ql {
+		if i < len(answers) && q.Type == QuestionTypeText && strings.TrimSpace(answers[i].Text) != "" {
+			texts = append(texts, answers[i].Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// SentimentPerAnswer scores each answered question on its own: numeric answers
+// directly and free text through analyzeText, once per answer. Choice answers carry
+// no sentiment and are skipped.
+func (ql QuestionList) SentimentPerAnswer(answers AnswerList, analyzeText func(text string) (score, confidence float64, source string)) []AnswerSentiment {
+	result := []AnswerSentiment{}
+	for i, q := range ql {
+		if i >= len(answers) {
+			break
+		}
+		if score, ok := q.Score(answers[i]); ok {
+			result = append(result, AnswerSentiment{QuestionIndex: i, Sentiment: score, Label: SentimentLabel(score), Confidence: 1, Source: SourceScale})
+			continue
+		}
+		if q.Type == QuestionTypeText && strings.TrimSpace(answers[i].Text) != "" {
+			score, confidence, source := analyzeText(answers[i].Text)
+			result = append(result, AnswerSentiment{QuestionIndex: i, Sentiment: score, Label: SentimentLabel(score), Confidence: confidence, Source: source})
+		}
+	}
+	return result
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// RiskSettings is one version of a company's risk tuning. Every change saves a new
+// version, so the latest row is the current one and earlier rows are the audit trail.
+// The weights only apply while the company has no trained churn model.
+type RiskSettings struct {
+	ID        uint `json:"id" gorm:"primaryKey"`
+	CompanyID uint `json:"company_id" gorm:"index"`
+	Version   int  `json:"version"`
+
+	HighRiskThreshold float64 `json:"high_risk_threshold"` // dashboard at-risk count and churn rate
+	WatchThreshold    float64 `json:"watch_threshold"`     // lowest score listed in attrition risks
+
+	SentimentWeight  float64 `json:"sentiment_weight"`
+	ResponseWeight   float64 `json:"response_weight"`
+	ActivityWeight   float64 `json:"activity_weight"`
+	EngagementWeight float64 `json:"engagement_weight"`
+	BurnoutWeight    float64 `json:"burnout_weight"`
+
+	LowSentimentCutoff     float64 `json:"low_sentiment_cutoff"`     // average sentiment below this
+	LowResponseRateCutoff  float64 `json:"low_response_rate_cutoff"` // response rate below this
+	InactiveDaysCutoff     int     `json:"inactive_days_cutoff"`     // more days inactive than this
+	NegativeRatioCutoff    float64 `json:"negative_ratio_cutoff"`    // share of negative responses above this
+	InfrequentLoginsCutoff int     `json:"infrequent_logins_cutoff"` // more days since login than this
+	HighBurnoutCutoff      float64 `json:"high_burnout_cutoff"`      // burnout index above this
+
+	CreatedBy uint      `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DefaultRiskSettings are the values used before a company saves its own
+func DefaultRiskSettings(companyID uint) *RiskSettings {
+	return &RiskSettings{
+		CompanyID:              companyID,
+		HighRiskThreshold:      0.7,
+		WatchThreshold:         0.5,
+		SentimentWeight:        0.40,
+		ResponseWeight:         0.15,
+		ActivityWeight:         0.15,
+		EngagementWeight:       0.15,
+		BurnoutWeight:          0.15,
+		LowSentimentCutoff:     -0.2,
+		LowResponseRateCutoff:  0.5,
+		InactiveDaysCutoff:     7,
+		NegativeRatioCutoff:    0.6,
+		InfrequentLoginsCutoff: 3,
+		HighBurnoutCutoff:      0.5,
+	}
+}
+
+// Weights returns the feature weights in the order the churn predictor expects
+func (s *RiskSettings) Weights() []float64 {
+	return []float64{s.SentimentWeight, s.ResponseWeight, s.ActivityWeight, s.EngagementWeight, s.BurnoutWeight}
+}
+
+func (s *RiskSettings) Validate() error {
+	if s.HighRiskThreshold <= 0 || s.HighRiskThreshold > 1 {
+		return errors.New("high_risk_threshold must be between 0 and 1")
+	}
+	if s.WatchThreshold <= 0 || s.WatchThreshold > s.HighRiskThreshold {
+		return errors.New("watch_threshold must be above 0 and at most high_risk_threshold")
+	}
+
+	total := 0.0
+	for _, weight := range s.Weights() {
+		if weight < 0 {
+			return errors.New("weights cannot be negative")
+		}
+		total += weight
+	}
+	// The scoring curve is centred for weights that sum to 1
+	if math.Abs(total-1) > 0.001 {
+		return errors.New("weights must sum to 1")
+	}
+
+	if s.LowSentimentCutoff < -1 || s.LowSentimentCutoff > 1 {
+		return errors.New("low_sentiment_cutoff must be between -1 and 1")
+	}
+	if s.LowResponseRateCutoff < 0 || s.LowResponseRateCutoff > 1 {
+		return errors.New("low_response_rate_cutoff must be between 0 and 1")
+	}
+	if s.NegativeRatioCutoff < 0 || s.NegativeRatioCutoff > 1 {
+		return errors.New("negative_ratio_cutoff must be between 0 and 1")
+	}
+	if s.HighBurnoutCutoff < 0 || s.HighBurnoutCutoff > 1 {
+		return errors.New("high_burnout_cutoff must be between 0 and 1")
+	}
+	if s.InactiveDaysCutoff < 0 || s.InfrequentLoginsCutoff < 0 {
+		return errors.New("day cutoffs cannot be negative")
+	}
+	return nil
+}
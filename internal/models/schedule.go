@@ -0,0 +1,117 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"gorm.io/gorm"
+)
+
+// SurveySchedule periodically opens a new instance of a template survey and invites employees.
+// It either uses a raw cron expression or fires on Weekday at Hour:Minute every IntervalWeeks weeks.
+type SurveySchedule struct {
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	CompanyID     uint           `json:"company_id" gorm:"index"`
+	SurveyID      uint           `json:"survey_id"` // template survey
+	Survey        Survey         `json:"survey,omitempty" gorm:"foreignKey:SurveyID"`
+	Cronspec      string         `json:"cronspec,omitempty"`
+	IntervalWeeks int            `json:"interval_weeks" gorm:"default:1"`
+	Weekday       int            `json:"weekday"` // 0 = Sunday
+	Hour          int            `json:"hour"`
+	Minute        int            `json:"minute"`
+	Timezone      string         `json:"timezone,omitempty"` // overrides the company timezone
+	IsActive      bool           `json:"is_active" gorm:"default:true"`
+	LastRunAt     *time.Time     `json:"last_run_at,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (s *SurveySchedule) Validate() error {
+	if s.Cronspec != "" {
+		if strings.Contains(s.Cronspec, "TZ=") {
+			return errors.New("set the timezone field instead of CRON_TZ")
+		}
+		if _, err := cron.ParseStandard(s.Cronspec); err != nil {
+			return fmt.Errorf("invalid cronspec: %v", err)
+		}
+	} else {
+		if s.IntervalWeeks < 1 || s.IntervalWeeks > 52 {
+			return errors.New("interval_weeks must be between 1 and 52")
+		}
+		if s.Weekday < 0 || s.Weekday > 6 {
+			return errors.New("weekday must be between 0 (Sunday) and 6 (Saturday)")
+		}
+		if s.Hour < 0 || s.Hour > 23 || s.Minute < 0 || s.Minute > 59 {
+			return errors.New("hour and minute must form a valid time of day")
+		}
+	}
+
+	if s.Timezone != "" {
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q", s.Timezone)
+		}
+	}
+	return nil
+}
+
+// Spec returns the cron expression for the scheduler, pinned to the schedule's
+// timezone or companyTZ when the schedule does not set one.
+func (s *SurveySchedule) Spec(companyTZ string) (string, error) {
+	tz := s.Timezone
+	if tz == "" {
+		tz = companyTZ
+	}
+	if tz == "" {
+		tz = "UTC"
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return "", fmt.Errorf("invalid timezone %q", tz)
+	}
+
+	expr := s.Cronspec
+	if expr == "" {
+		expr = fmt.Sprintf("%d %d * * %d", s.Minute, s.Hour, s.Weekday)
+	}
+
+	spec := fmt.Sprintf("CRON_TZ=%s %s", tz, expr)
+	if _, err := cron.ParseStandard(spec); err != nil {
+		return "", err
+	}
+	return spec, nil
+}
+
+// triggerSlack tolerates a worker clock running slightly behind the scheduler's
+const triggerSlack = time.Minute
+
+// Due reports whether a trigger at now should open a new survey. A run at or after
+// the latest trigger means it was already handled, e.g. by a retry of the same task or
+// a second worker, so only the first run per trigger period is due. Cron cannot
+// express "every N weeks", so the scheduler fires weekly and skips the weeks in between.
+func (s *SurveySchedule) Due(now time.Time, companyTZ string) bool {
+	if s.LastRunAt == nil {
+		return true
+	}
+
+	spec, err := s.Spec(companyTZ)
+	if err != nil {
+		return false
+	}
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return false
+	}
+	if schedule.Next(*s.LastRunAt).After(now.Add(triggerSlack)) {
+		return false
+	}
+
+	if s.Cronspec != "" || s.IntervalWeeks <= 1 {
+		return true
+	}
+	// Allow a day of slack so a late previous run doesn't push the schedule back a full interval
+	interval := time.Duration(s.IntervalWeeks) * 7 * 24 * time.Hour
+	return now.Sub(*s.LastRunAt) >= interval-24*time.Hour
+}
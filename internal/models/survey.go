@@ -31,8 +31,12 @@ type Survey struct {
 	CompanyID   uint           `json:"company_id"`
 	Company     Company        `json:"company,omitempty" gorm:"foreignKey:CompanyID"`
 	Title       string         `json:"title" gorm:"not null"`
-	Questions   StringArray    `json:"questions" gorm:"type:jsonb"`
+	Questions   QuestionList   `json:"questions" gorm:"type:jsonb"`
 	IsActive    bool           `json:"is_active" gorm:"default:true"`
+	IsTemplate  bool           `json:"is_template" gorm:"default:false"` // only cloned by schedules, never sent
+	IsAnonymous bool           `json:"is_anonymous" gorm:"default:false"`
+	AnonymitySalt string       `json:"-"`
+	ScheduleID  *uint          `json:"schedule_id,omitempty" gorm:"index"`
 	Responses   []SurveyResponse `json:"responses,omitempty" gorm:"foreignKey:SurveyID"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
@@ -43,20 +47,68 @@ type SurveyResponse struct {
 	ID         uint           `json:"id" gorm:"primaryKey"`
 	SurveyID   uint           `json:"survey_id"`
 	Survey     Survey         `json:"survey,omitempty" gorm:"foreignKey:SurveyID"`
-	UserID     uint           `json:"user_id"`
+	UserID     *uint          `json:"user_id"` // nil for anonymous surveys
 	User       User           `json:"user,omitempty" gorm:"foreignKey:UserID"`
 	Responses  StringArray    `json:"responses" gorm:"type:jsonb"`
+	Answers    AnswerList     `json:"answers" gorm:"type:jsonb"`
 	Sentiment  float64        `json:"sentiment" gorm:"default:0"` // -1 to 1
+	SentimentStatus string    `json:"sentiment_status" gorm:"default:scored"` // pending until the worker scores it
+	Language   string         `json:"language,omitempty"` // detected from the free-text answers when scored
+	BurnoutIndex *float64     `json:"burnout_index,omitempty"` // 0 to 1 from the free-text answers; nil without any
+	AnswerSentiments []AnswerSentiment `json:"answer_sentiments,omitempty" gorm:"foreignKey:ResponseID"`
+	Aspects    []ResponseAspect `json:"aspects,omitempty" gorm:"foreignKey:ResponseID"`
 	CreatedAt  time.Time      `json:"created_at"`
 	UpdatedAt  time.Time      `json:"updated_at"`
 }
 
+const (
+	SentimentPending = "pending"
+	SentimentScored  = "scored"
+	SentimentFailed  = "failed"
+)
+
+// AnonymousTime coarsens a timestamp stored with an anonymous answer to the day, so
+// rows can't be lined up by time with anything that names the respondent
+func AnonymousTime(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// Scored reports whether Sentiment holds a real score rather than the placeholder
+// saved before the worker runs
+func (r SurveyResponse) Scored() bool {
+	return r.SentimentStatus == SentimentScored || r.SentimentStatus == ""
+}
+
+// ScoredResponses drops responses whose sentiment hasn't been scored, so placeholders
+// don't pull averages towards neutral
+func ScoredResponses(responses []SurveyResponse) []SurveyResponse {
+	scored := make([]SurveyResponse, 0, len(responses))
+	for _, response := range responses {
+		if response.Scored() {
+			scored = append(scored, response)
+		}
+	}
+	return scored
+}
+
+// SurveyParticipation records that someone answered an anonymous survey without
+// saying who. Marker is an HMAC of the user ID that cannot be reversed from the database alone.
+type SurveyParticipation struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	SurveyID  uint      `json:"survey_id" gorm:"uniqueIndex:idx_survey_participations_marker"`
+	Marker    string    `json:"-" gorm:"uniqueIndex:idx_survey_participations_marker;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type AttritionRisk struct {
 	ID         uint           `json:"id" gorm:"primaryKey"`
 	UserID     uint           `json:"user_id"`
 	User       User           `json:"user,omitempty" gorm:"foreignKey:UserID"`
 	RiskScore  float64        `json:"risk_score" gorm:"default:0"` // 0 to 1
 	Factors    StringArray    `json:"factors" gorm:"type:jsonb"`
+	Features   *ChurnFeatureSnapshot `json:"features,omitempty" gorm:"type:jsonb"`
+	Contributions ContributionList   `json:"contributions" gorm:"type:jsonb"`
+	ModelVersion *int         `json:"model_version,omitempty"` // nil when scored with the default weights
 	CreatedAt  time.Time      `json:"created_at"`
 	UpdatedAt  time.Time      `json:"updated_at"`
 }
\ No newline at end of file
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrSurveyTokenMismatch = errors.New("survey token does not belong to this survey")
+	ErrSurveyTokenExpired  = errors.New("survey token has expired")
+	ErrSurveyTokenUsed     = errors.New("survey token has already been used")
+)
+
+// SurveyToken is a single-use invitation for one user to answer one survey.
+// Only the SHA-256 hash of the token is stored; the raw value lives in the invitation link.
+type SurveyToken struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	SurveyID  uint       `json:"survey_id" gorm:"index"`
+	UserID    uint       `json:"user_id" gorm:"index"`
+	TokenHash string     `json:"-" gorm:"uniqueIndex;not null"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (t *SurveyToken) Validate(surveyID uint, now time.Time) error {
+	if t.SurveyID != surveyID {
+		return ErrSurveyTokenMismatch
+	}
+	if t.UsedAt != nil {
+		return ErrSurveyTokenUsed
+	}
+	if !now.Before(t.ExpiresAt) {
+		return ErrSurveyTokenExpired
+	}
+	return nil
+}
@@ -1,18 +1,42 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"time"
 	"gorm.io/gorm"
 )
 
+const (
+	RoleHRAdmin       = "hr_admin"
+	RolePeopleManager = "people_manager"
+	RoleEmployee      = "employee"
+	RoleExecutive     = "executive" // read-only access to company-wide analytics
+)
+
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleHRAdmin, RolePeopleManager, RoleEmployee, RoleExecutive:
+		return true
+	}
+	return false
+}
+
 type User struct {
 	ID        uint           `json:"id" gorm:"primaryKey"`
 	Email     string         `json:"email" gorm:"uniqueIndex;not null"`
 	Password  string         `json:"-" gorm:"not null"`
 	Name      string         `json:"name" gorm:"not null"`
-	Role      string         `json:"role" gorm:"default:employee"` // hr_admin, employee
+	Role      string         `json:"role" gorm:"default:employee"` // hr_admin, people_manager, employee, executive
 	CompanyID uint           `json:"company_id"`
 	Company   Company        `json:"company,omitempty" gorm:"foreignKey:CompanyID"`
+	DepartmentID *uint       `json:"department_id,omitempty" gorm:"index"`
+	Department   *Department `json:"department,omitempty" gorm:"foreignKey:DepartmentID"`
+	TeamID       *uint       `json:"team_id,omitempty" gorm:"index"`
+	Team         *Team       `json:"team,omitempty" gorm:"foreignKey:TeamID"`
+	ManagerID    *uint       `json:"manager_id,omitempty" gorm:"index"`
+	Manager      *User       `json:"manager,omitempty" gorm:"foreignKey:ManagerID"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
@@ -23,12 +47,61 @@ type Company struct {
 	Name         string         `json:"name" gorm:"not null"`
 	Plan         string         `json:"plan" gorm:"default:free"` // free, premium
 	Language     string         `json:"language" gorm:"default:en"` // en, hi, ta
+	Timezone     string         `json:"timezone" gorm:"default:UTC"` // IANA name, e.g. Asia/Kolkata
 	SMTPHost     string         `json:"smtp_host,omitempty"`
 	SMTPPort     int            `json:"smtp_port,omitempty" gorm:"default:587"`
 	SMTPUser     string         `json:"smtp_user,omitempty"`
 	SMTPPassword string         `json:"-" gorm:"column:smtp_password"`
+	SentimentProviders ProviderChains `json:"sentiment_providers,omitempty" gorm:"type:jsonb"` // overrides SENTIMENT_PROVIDERS per language
+	AspectKeywords AspectKeywords `json:"aspect_keywords,omitempty" gorm:"type:jsonb"` // extra keywords per aspect, on top of the built-in taxonomy
 	Users        []User         `json:"users,omitempty" gorm:"foreignKey:CompanyID"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
-}
\ No newline at end of file
+}
+
+// ProviderChains maps a language (or "default") to the ordered sentiment
+// providers tried for it
+type ProviderChains map[string][]string
+
+func (p *ProviderChains) Scan(value interface{}) error {
+	if value == nil {
+		*p = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, p)
+}
+
+func (p ProviderChains) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return json.Marshal(p)
+}
+
+// AspectKeywords maps an aspect, built-in or the company's own, to extra keywords
+// that mark an answer as being about it
+type AspectKeywords map[string][]string
+
+func (a *AspectKeywords) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, a)
+}
+
+func (a AspectKeywords) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return json.Marshal(a)
+}
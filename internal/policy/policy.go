@@ -0,0 +1,138 @@
+// Package policy decides what each role may do and which employees' data it may see.
+package policy
+
+import (
+	"context"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+	"github.com/VinVorteX/NoBurn/internal/repository"
+)
+
+type Action string
+
+const (
+	ManageEmployees   Action = "employees:manage"
+	ViewEmployees     Action = "employees:view"
+	ManageSurveys     Action = "surveys:manage"
+	ViewSurveyResults Action = "surveys:results"
+	ViewAnalytics     Action = "analytics:view"
+	ManageSettings    Action = "settings:manage"
+)
+
+var permissions = map[string][]Action{
+	models.RoleHRAdmin: {
+		ManageEmployees, ViewEmployees, ManageSurveys, ViewSurveyResults, ViewAnalytics, ManageSettings,
+	},
+	models.RoleExecutive: {
+		ViewEmployees, ViewSurveyResults, ViewAnalytics,
+	},
+	// Scoped to their reports, see ScopeFor
+	models.RolePeopleManager: {
+		ViewEmployees, ViewAnalytics,
+	},
+	models.RoleEmployee: {},
+}
+
+// Can reports whether role is allowed to perform action
+func Can(role string, action Action) bool {
+	for _, a := range permissions[role] {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Scope is the set of employees whose data a user may see
+type Scope struct {
+	all       bool
+	userIDs   map[uint]bool
+	managerID uint // set for people managers, whose former reports are no longer in userIDs
+}
+
+// CompanyScope sees every employee in the company
+func CompanyScope() Scope {
+	return Scope{all: true}
+}
+
+// UsersScope sees only the given employees
+func UsersScope(userIDs []uint) Scope {
+	s := Scope{userIDs: make(map[uint]bool, len(userIDs))}
+	for _, id := range userIDs {
+		s.userIDs[id] = true
+	}
+	return s
+}
+
+// ScopeFor returns company-wide scope for HR admins and executives, the direct and
+// indirect reports for people managers, and only the user themselves otherwise.
+// Reports are looked up within the tenant in ctx.
+func ScopeFor(ctx context.Context, user *models.User) (Scope, error) {
+	switch user.Role {
+	case models.RoleHRAdmin, models.RoleExecutive:
+		return CompanyScope(), nil
+	case models.RolePeopleManager:
+		ids, err := repository.NewUserRepository().ForTenant(ctx).GetReportIDs(user.ID)
+		if err != nil {
+			return Scope{}, err
+		}
+		scope := UsersScope(ids)
+		scope.managerID = user.ID
+		return scope, nil
+	default:
+		return UsersScope([]uint{user.ID}), nil
+	}
+}
+
+func (s Scope) All() bool {
+	return s.all
+}
+
+func (s Scope) Includes(userID uint) bool {
+	return s.all || s.userIDs[userID]
+}
+
+func (s Scope) Users(users []models.User) []models.User {
+	if s.all {
+		return users
+	}
+	result := []models.User{}
+	for _, u := range users {
+		if s.userIDs[u.ID] {
+			result = append(result, u)
+		}
+	}
+	return result
+}
+
+func (s Scope) Risks(risks []models.AttritionRisk) []models.AttritionRisk {
+	if s.all {
+		return risks
+	}
+	result := []models.AttritionRisk{}
+	for _, risk := range risks {
+		if s.userIDs[risk.UserID] {
+			result = append(result, risk)
+		}
+	}
+	return result
+}
+
+// Exits keeps the exits of employees who reported to someone in scope when they left
+func (s Scope) Exits(exits []models.EmployeeExit) []models.EmployeeExit {
+	if s.all {
+		return exits
+	}
+	result := []models.EmployeeExit{}
+	for _, exit := range exits {
+		if s.userIDs[exit.UserID] || (exit.ManagerID != nil && s.managedBy(*exit.ManagerID)) {
+			result = append(result, exit)
+		}
+	}
+	return result
+}
+
+// managedBy reports whether managerID is the scope's manager or one of their reports
+func (s Scope) managedBy(managerID uint) bool {
+	return (s.managerID != 0 && managerID == s.managerID) || s.userIDs[managerID]
+}
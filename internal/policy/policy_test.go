@@ -0,0 +1,78 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+)
+
+func TestCan(t *testing.T) {
+	tests := []struct {
+		role     string
+		action   Action
+		expected bool
+	}{
+		{models.RoleHRAdmin, ManageEmployees, true},
+		{models.RoleHRAdmin, ManageSettings, true},
+		{models.RoleExecutive, ViewAnalytics, true},
+		{models.RoleExecutive, ManageSurveys, false},
+		{models.RolePeopleManager, ViewAnalytics, true},
+		{models.RolePeopleManager, ViewSurveyResults, false},
+		{models.RoleEmployee, ViewAnalytics, false},
+		{"unknown", ViewEmployees, false},
+	}
+
+	for _, tt := range tests {
+		if result := Can(tt.role, tt.action); result != tt.expected {
+			t.Errorf("Can(%q, %q): expected %v, got %v", tt.role, tt.action, tt.expected, result)
+		}
+	}
+}
+
+func TestScopeFilters(t *testing.T) {
+	users := []models.User{{ID: 1}, {ID: 2}, {ID: 3}}
+	risks := []models.AttritionRisk{{UserID: 1}, {UserID: 3}}
+
+	scope := UsersScope([]uint{2, 3})
+	if scope.All() || scope.Includes(1) || !scope.Includes(3) {
+		t.Errorf("Expected scope to include only users 2 and 3")
+	}
+	if got := scope.Users(users); len(got) != 2 || got[0].ID != 2 {
+		t.Errorf("Expected users 2 and 3, got %+v", got)
+	}
+	if got := scope.Risks(risks); len(got) != 1 || got[0].UserID != 3 {
+		t.Errorf("Expected only user 3's risk, got %+v", got)
+	}
+
+	company := CompanyScope()
+	if !company.Includes(42) || len(company.Users(users)) != 3 || len(company.Risks(risks)) != 2 {
+		t.Errorf("Expected company scope to include everyone")
+	}
+
+	if got := UsersScope(nil).Users(users); len(got) != 0 {
+		t.Errorf("Expected empty scope to see no users, got %d", len(got))
+	}
+}
+
+func TestScopeExits(t *testing.T) {
+	manager, report := uint(10), uint(11)
+	exits := []models.EmployeeExit{
+		{UserID: 1, ManagerID: &manager},
+		{UserID: 2, ManagerID: &report},
+		{UserID: 3},
+	}
+
+	scope := UsersScope([]uint{report})
+	scope.managerID = manager
+	if got := scope.Exits(exits); len(got) != 2 || got[0].UserID != 1 || got[1].UserID != 2 {
+		t.Errorf("Expected exits of the manager's former reports, got %+v", got)
+	}
+
+	if got := UsersScope([]uint{report}).Exits(exits); len(got) != 1 || got[0].UserID != 2 {
+		t.Errorf("Expected only exits under user %d, got %+v", report, got)
+	}
+
+	if got := CompanyScope().Exits(exits); len(got) != 3 {
+		t.Errorf("Expected company scope to see every exit, got %d", len(got))
+	}
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserActivityRepository struct {
+	tenant tenantScope
+}
+
+func NewUserActivityRepository() *UserActivityRepository {
+	return &UserActivityRepository{}
+}
+
+// ForTenant returns a repository that only sees the activity of the tenant's users
+func (r *UserActivityRepository) ForTenant(ctx context.Context) *UserActivityRepository {
+	return &UserActivityRepository{tenant: scopeFrom(ctx)}
+}
+
+// Get returns the user's activity, or an empty record if nothing has been tracked yet
+func (r *UserActivityRepository) Get(userID uint) (*models.UserActivity, error) {
+	var activity models.UserActivity
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.whereIn(db, "user_id", "users").Where("user_id = ?", userID).First(&activity).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &models.UserActivity{UserID: userID}, nil
+	}
+	return &activity, err
+}
+
+func (r *UserActivityRepository) RecordLogin(userID uint, at time.Time) error {
+	return r.upsert(&models.UserActivity{UserID: userID, LastLoginAt: &at}, map[string]interface{}{
+		"last_login_at": at,
+	})
+}
+
+func (r *UserActivityRepository) RecordInvitation(userID uint) error {
+	return r.upsert(&models.UserActivity{UserID: userID, InvitationsSent: 1}, map[string]interface{}{
+		"invitations_sent": gorm.Expr("user_activities.invitations_sent + 1"),
+	})
+}
+
+// RecordResponse records a survey response. invited is set when it redeemed an
+// invitation, which is the only case that counts towards InvitationsAnswered. at is
+// nil for anonymous surveys, which leave updated_at alone as well.
+func (r *UserActivityRepository) RecordResponse(userID uint, at *time.Time, invited bool) error {
+	activity := &models.UserActivity{UserID: userID, LastResponseAt: at}
+	updates := map[string]interface{}{}
+	if invited {
+		activity.InvitationsAnswered = 1
+		updates["invitations_answered"] = gorm.Expr("user_activities.invitations_answered + 1")
+	}
+	if at == nil {
+		if len(updates) == 0 {
+			return nil
+		}
+		activity.UpdatedAt = models.AnonymousTime(time.Now())
+		return r.save(activity, updates)
+	}
+	updates["last_response_at"] = *at
+	return r.upsert(activity, updates)
+}
+
+func (r *UserActivityRepository) upsert(activity *models.UserActivity, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now()
+	return r.save(activity, updates)
+}
+
+func (r *UserActivityRepository) save(activity *models.UserActivity, updates map[string]interface{}) error {
+	return r.tenant.run(func(db *gorm.DB) error {
+		if err := r.tenant.owns(db, &models.User{}, activity.UserID); err != nil {
+			return err
+		}
+		return db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.Assignments(updates),
+		}).Create(activity).Error
+	})
+}
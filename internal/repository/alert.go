@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+	"gorm.io/gorm"
+)
+
+type AlertRuleRepository struct {
+	tenant tenantScope
+}
+
+func NewAlertRuleRepository() *AlertRuleRepository {
+	return &AlertRuleRepository{}
+}
+
+// ForTenant returns a repository that only sees the tenant's alert rules
+func (r *AlertRuleRepository) ForTenant(ctx context.Context) *AlertRuleRepository {
+	return &AlertRuleRepository{tenant: scopeFrom(ctx)}
+}
+
+func (r *AlertRuleRepository) Create(rule *models.AlertRule) error {
+	if !r.tenant.allows(rule.CompanyID) {
+		return ErrWrongTenant
+	}
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Create(rule).Error
+	})
+}
+
+func (r *AlertRuleRepository) GetByID(id uint) (*models.AlertRule, error) {
+	var rule models.AlertRule
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").First(&rule, id).Error
+	})
+	return &rule, err
+}
+
+func (r *AlertRuleRepository) GetByCompanyID(companyID uint) ([]models.AlertRule, error) {
+	var rules []models.AlertRule
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Where("company_id = ?", companyID).Order("id").Find(&rules).Error
+	})
+	return rules, err
+}
+
+// GetEffective returns the rules to evaluate for a company: its active rules, or the
+// defaults if it has never configured any
+func (r *AlertRuleRepository) GetEffective(companyID uint) ([]models.AlertRule, error) {
+	rules, err := r.GetByCompanyID(companyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		settings, err := (&RiskSettingsRepository{tenant: r.tenant}).GetCurrent(companyID)
+		if err != nil {
+			return nil, err
+		}
+		return models.DefaultAlertRules(companyID, settings.HighRiskThreshold), nil
+	}
+
+	active := []models.AlertRule{}
+	for _, rule := range rules {
+		if rule.IsActive {
+			active = append(active, rule)
+		}
+	}
+	return active, nil
+}
+
+func (r *AlertRuleRepository) Update(rule *models.AlertRule) error {
+	if !r.tenant.allows(rule.CompanyID) {
+		return ErrWrongTenant
+	}
+	return r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Model(rule).
+			Select("Name", "Type", "Threshold", "WindowDays", "Count", "CooldownDays", "IsActive").
+			Updates(rule).Error
+	})
+}
+
+func (r *AlertRuleRepository) Delete(id uint) error {
+	return r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Delete(&models.AlertRule{}, id).Error
+	})
+}
+
+type AlertRepository struct {
+	tenant tenantScope
+}
+
+func NewAlertRepository() *AlertRepository {
+	return &AlertRepository{}
+}
+
+// ForTenant returns a repository that only sees the tenant's alerts
+func (r *AlertRepository) ForTenant(ctx context.Context) *AlertRepository {
+	return &AlertRepository{tenant: scopeFrom(ctx)}
+}
+
+func (r *AlertRepository) Create(alert *models.Alert) error {
+	if !r.tenant.allows(alert.CompanyID) {
+		return ErrWrongTenant
+	}
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Create(alert).Error
+	})
+}
+
+// GetByUserID returns the user's alerts created on or after since
+func (r *AlertRepository) GetByUserID(userID uint, since time.Time) ([]models.Alert, error) {
+	var alerts []models.Alert
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Where("user_id = ? AND created_at >= ?", userID, since).Order("created_at DESC").Find(&alerts).Error
+	})
+	return alerts, err
+}
+
+// GetByCompanyID returns the company's alerts created on or after since, newest first
+func (r *AlertRepository) GetByCompanyID(companyID uint, since time.Time) ([]models.Alert, error) {
+	var alerts []models.Alert
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Where("company_id = ? AND created_at >= ?", companyID, since).
+			Preload("User").
+			Order("created_at DESC").
+			Find(&alerts).Error
+	})
+	return alerts, err
+}
@@ -1,33 +1,75 @@
 package repository
 
 import (
-	"github.com/VinVorteX/NoBurn/internal/database"
+	"context"
+	"time"
+
 	"github.com/VinVorteX/NoBurn/internal/models"
+	"gorm.io/gorm"
 )
 
-type AttritionRepository struct{}
+type AttritionRepository struct {
+	tenant tenantScope
+}
 
 func NewAttritionRepository() *AttritionRepository {
 	return &AttritionRepository{}
 }
 
+// ForTenant returns a repository that only sees the risks of the tenant's employees
+func (r *AttritionRepository) ForTenant(ctx context.Context) *AttritionRepository {
+	return &AttritionRepository{tenant: scopeFrom(ctx)}
+}
+
 func (r *AttritionRepository) Create(risk *models.AttritionRisk) error {
-	return database.DB.Create(risk).Error
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Create(risk).Error
+	})
 }
 
 func (r *AttritionRepository) GetByUserID(userID uint) (*models.AttritionRisk, error) {
 	var risk models.AttritionRisk
-	err := database.DB.Where("user_id = ?", userID).Order("created_at DESC").First(&risk).Error
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.whereIn(db, "user_id", "users").Where("user_id = ?", userID).Order("created_at DESC, id DESC").First(&risk).Error
+	})
 	return &risk, err
 }
 
-func (r *AttritionRepository) GetHighRiskUsers(companyID uint, threshold float64) ([]models.AttritionRisk, error) {
+// GetLatestBefore returns the last risk calculated for the user before t
+func (r *AttritionRepository) GetLatestBefore(userID uint, t time.Time) (*models.AttritionRisk, error) {
+	var risk models.AttritionRisk
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.whereIn(db, "user_id", "users").Where("user_id = ? AND created_at <= ?", userID, t).Order("created_at DESC, id DESC").First(&risk).Error
+	})
+	return &risk, err
+}
+
+// GetCurrentRisks returns each current employee's latest risk at or above threshold.
+// Earlier calculations are history and are left out, so every employee appears once.
+// Latest means by created_at then id, the same order GetHistory uses.
+func (r *AttritionRepository) GetCurrentRisks(companyID uint, threshold float64) ([]models.AttritionRisk, error) {
+	var risks []models.AttritionRisk
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "users.company_id").
+			Joins("JOIN users ON users.id = attrition_risks.user_id").
+			Where(`NOT EXISTS (SELECT 1 FROM attrition_risks newer WHERE newer.user_id = attrition_risks.user_id
+				AND (newer.created_at > attrition_risks.created_at OR (newer.created_at = attrition_risks.created_at AND newer.id > attrition_risks.id)))`).
+			Where("users.company_id = ? AND users.deleted_at IS NULL AND attrition_risks.risk_score >= ?", companyID, threshold).
+			Preload("User").
+			Order("attrition_risks.risk_score DESC").
+			Find(&risks).Error
+	})
+	return risks, err
+}
+
+// GetHistory returns the user's risks calculated on or after since, oldest first
+func (r *AttritionRepository) GetHistory(userID uint, since time.Time) ([]models.AttritionRisk, error) {
 	var risks []models.AttritionRisk
-	err := database.DB.
-		Joins("JOIN users ON users.id = attrition_risks.user_id").
-		Where("users.company_id = ? AND attrition_risks.risk_score >= ?", companyID, threshold).
-		Preload("User").
-		Order("attrition_risks.risk_score DESC").
-		Find(&risks).Error
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.whereIn(db, "user_id", "users").
+			Where("user_id = ? AND created_at >= ?", userID, since).
+			Order("created_at ASC, id ASC").
+			Find(&risks).Error
+	})
 	return risks, err
-}
\ No newline at end of file
+}
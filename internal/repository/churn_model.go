@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+	"gorm.io/gorm"
+)
+
+type ChurnModelRepository struct {
+	tenant tenantScope
+}
+
+func NewChurnModelRepository() *ChurnModelRepository {
+	return &ChurnModelRepository{}
+}
+
+// ForTenant returns a repository that only sees the tenant's churn models
+func (r *ChurnModelRepository) ForTenant(ctx context.Context) *ChurnModelRepository {
+	return &ChurnModelRepository{tenant: scopeFrom(ctx)}
+}
+
+// GetActive returns the company's current model, or gorm.ErrRecordNotFound if none has been trained
+func (r *ChurnModelRepository) GetActive(companyID uint) (*models.ChurnModel, error) {
+	var model models.ChurnModel
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Where("company_id = ? AND is_active = ?", companyID, true).Order("version DESC").First(&model).Error
+	})
+	return &model, err
+}
+
+func (r *ChurnModelRepository) GetByCompanyID(companyID uint) ([]models.ChurnModel, error) {
+	var churnModels []models.ChurnModel
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Where("company_id = ?", companyID).Order("version DESC").Find(&churnModels).Error
+	})
+	return churnModels, err
+}
+
+// Save stores model as the company's next version and makes it the active one
+func (r *ChurnModelRepository) Save(model *models.ChurnModel) error {
+	if !r.tenant.allows(model.CompanyID) {
+		return ErrWrongTenant
+	}
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			var latest int
+			if err := tx.Model(&models.ChurnModel{}).
+				Where("company_id = ?", model.CompanyID).
+				Select("COALESCE(MAX(version), 0)").
+				Scan(&latest).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Model(&models.ChurnModel{}).
+				Where("company_id = ? AND is_active = ?", model.CompanyID, true).
+				Update("is_active", false).Error; err != nil {
+				return err
+			}
+
+			model.Version = latest + 1
+			model.IsActive = true
+			return tx.Create(model).Error
+		})
+	})
+}
+
+// LabelledSnapshot pairs the features of an employee's last risk calculation with
+// whether they went on to leave
+type LabelledSnapshot struct {
+	UserID   uint
+	Features models.ChurnFeatureSnapshot
+	Left     bool
+}
+
+// GetTrainingData returns one snapshot per employee, including former employees.
+// Employees with a voluntary exit count as having left, and only calculations made
+// before they left are used. Involuntary exits say nothing about whether the employee
+// would have quit, and deleted users without an exit record have no known outcome,
+// so both are left out.
+func (r *ChurnModelRepository) GetTrainingData(companyID uint) ([]LabelledSnapshot, error) {
+	type row struct {
+		UserID   uint
+		Features models.ChurnFeatureSnapshot
+		ExitType *string
+	}
+
+	var rows []row
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db.Table("attrition_risks"), "users.company_id").
+			Select("attrition_risks.user_id, attrition_risks.features, employee_exits.exit_type").
+			Joins("JOIN users ON users.id = attrition_risks.user_id").
+			Joins("LEFT JOIN employee_exits ON employee_exits.user_id = attrition_risks.user_id").
+			Where("users.company_id = ? AND attrition_risks.features IS NOT NULL", companyID).
+			Where("(employee_exits.id IS NULL AND users.deleted_at IS NULL) OR (employee_exits.exit_type = ? AND attrition_risks.created_at <= employee_exits.exit_date)", models.ExitVoluntary).
+			Order("attrition_risks.user_id, attrition_risks.created_at DESC").
+			Scan(&rows).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Rows are newest first per user, so keep the first one seen
+	result := []LabelledSnapshot{}
+	for i, row := range rows {
+		if i > 0 && rows[i-1].UserID == row.UserID {
+			continue
+		}
+		result = append(result, LabelledSnapshot{UserID: row.UserID, Features: row.Features, Left: row.ExitType != nil})
+	}
+	return result, nil
+}
@@ -1,26 +1,57 @@
 package repository
 
 import (
-	"github.com/VinVorteX/NoBurn/internal/database"
+	"context"
+
 	"github.com/VinVorteX/NoBurn/internal/models"
+	"gorm.io/gorm"
 )
 
-type CompanyRepository struct{}
+type CompanyRepository struct {
+	tenant tenantScope
+}
 
 func NewCompanyRepository() *CompanyRepository {
 	return &CompanyRepository{}
 }
 
+// ForTenant returns a repository that only sees the tenant's own company
+func (r *CompanyRepository) ForTenant(ctx context.Context) *CompanyRepository {
+	return &CompanyRepository{tenant: scopeFrom(ctx)}
+}
+
+// Create registers a new company. A tenant cannot create another company, so only
+// the unscoped repository used during sign-up can.
 func (r *CompanyRepository) Create(company *models.Company) error {
-	return database.DB.Create(company).Error
+	if r.tenant.scoped {
+		return ErrWrongTenant
+	}
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Create(company).Error
+	})
 }
 
 func (r *CompanyRepository) GetByID(id uint) (*models.Company, error) {
 	var company models.Company
-	err := database.DB.First(&company, id).Error
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "id").First(&company, id).Error
+	})
 	return &company, err
 }
 
 func (r *CompanyRepository) Update(company *models.Company) error {
-	return database.DB.Save(company).Error
-}
\ No newline at end of file
+	if !r.tenant.allows(company.ID) {
+		return ErrWrongTenant
+	}
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Save(company).Error
+	})
+}
+
+func (r *CompanyRepository) GetAll() ([]models.Company, error) {
+	var companies []models.Company
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "id").Find(&companies).Error
+	})
+	return companies, err
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+	"gorm.io/gorm"
+)
+
+type ExitRepository struct {
+	tenant tenantScope
+}
+
+func NewExitRepository() *ExitRepository {
+	return &ExitRepository{}
+}
+
+// ForTenant returns a repository that only sees the tenant's exits
+func (r *ExitRepository) ForTenant(ctx context.Context) *ExitRepository {
+	return &ExitRepository{tenant: scopeFrom(ctx)}
+}
+
+// Offboard records the exit and soft-deletes the employee in one transaction. Their
+// direct reports move up to the employee's own manager.
+func (r *ExitRepository) Offboard(exit *models.EmployeeExit) error {
+	if !r.tenant.allows(exit.CompanyID) {
+		return ErrWrongTenant
+	}
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(exit).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.User{}).
+				Where("company_id = ? AND manager_id = ?", exit.CompanyID, exit.UserID).
+				Update("manager_id", exit.ManagerID).Error; err != nil {
+				return err
+			}
+			return tx.Where("company_id = ?", exit.CompanyID).Delete(&models.User{}, exit.UserID).Error
+		})
+	})
+}
+
+// GetByCompanyID returns exits on or after since, newest first, with the departed
+// employee's record even though it is soft-deleted
+func (r *ExitRepository) GetByCompanyID(companyID uint, since time.Time) ([]models.EmployeeExit, error) {
+	var exits []models.EmployeeExit
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").
+			Where("company_id = ? AND exit_date >= ?", companyID, since).
+			Preload("User", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+			Order("exit_date DESC").
+			Find(&exits).Error
+	})
+	return exits, err
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+	"gorm.io/gorm"
+)
+
+type DepartmentRepository struct {
+	tenant tenantScope
+}
+
+func NewDepartmentRepository() *DepartmentRepository {
+	return &DepartmentRepository{}
+}
+
+// ForTenant returns a repository that only sees the tenant's departments
+func (r *DepartmentRepository) ForTenant(ctx context.Context) *DepartmentRepository {
+	return &DepartmentRepository{tenant: scopeFrom(ctx)}
+}
+
+func (r *DepartmentRepository) Create(department *models.Department) error {
+	if !r.tenant.allows(department.CompanyID) {
+		return ErrWrongTenant
+	}
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Create(department).Error
+	})
+}
+
+func (r *DepartmentRepository) GetByID(id uint) (*models.Department, error) {
+	var department models.Department
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").First(&department, id).Error
+	})
+	return &department, err
+}
+
+func (r *DepartmentRepository) GetByCompanyID(companyID uint) ([]models.Department, error) {
+	var departments []models.Department
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Where("company_id = ?", companyID).Order("name").Find(&departments).Error
+	})
+	return departments, err
+}
+
+// FirstOrCreate finds a department by case-insensitive name, creating it if missing
+func (r *DepartmentRepository) FirstOrCreate(companyID uint, name string) (*models.Department, error) {
+	if !r.tenant.allows(companyID) {
+		return nil, ErrWrongTenant
+	}
+	var department models.Department
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return db.
+			Where("company_id = ? AND LOWER(name) = LOWER(?)", companyID, name).
+			Attrs(models.Department{CompanyID: companyID, Name: name}).
+			FirstOrCreate(&department).Error
+	})
+	return &department, err
+}
+
+func (r *DepartmentRepository) Update(department *models.Department) error {
+	if !r.tenant.allows(department.CompanyID) {
+		return ErrWrongTenant
+	}
+	return r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Model(department).Select("Name").Updates(department).Error
+	})
+}
+
+// Delete removes the department and detaches its teams and members
+func (r *DepartmentRepository) Delete(id uint) error {
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			var department models.Department
+			if err := r.tenant.where(tx, "company_id").First(&department, id).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.Team{}).Where("department_id = ?", id).Update("department_id", nil).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.User{}).Where("department_id = ?", id).Update("department_id", nil).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&department).Error
+		})
+	})
+}
+
+type TeamRepository struct {
+	tenant tenantScope
+}
+
+func NewTeamRepository() *TeamRepository {
+	return &TeamRepository{}
+}
+
+// ForTenant returns a repository that only sees the tenant's teams
+func (r *TeamRepository) ForTenant(ctx context.Context) *TeamRepository {
+	return &TeamRepository{tenant: scopeFrom(ctx)}
+}
+
+func (r *TeamRepository) Create(team *models.Team) error {
+	if !r.tenant.allows(team.CompanyID) {
+		return ErrWrongTenant
+	}
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Create(team).Error
+	})
+}
+
+func (r *TeamRepository) GetByID(id uint) (*models.Team, error) {
+	var team models.Team
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Preload("Department").First(&team, id).Error
+	})
+	return &team, err
+}
+
+func (r *TeamRepository) GetByCompanyID(companyID uint) ([]models.Team, error) {
+	var teams []models.Team
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Preload("Department").Where("company_id = ?", companyID).Order("name").Find(&teams).Error
+	})
+	return teams, err
+}
+
+// FirstOrCreate finds a team by case-insensitive name, creating it under departmentID if missing
+func (r *TeamRepository) FirstOrCreate(companyID uint, name string, departmentID *uint) (*models.Team, error) {
+	if !r.tenant.allows(companyID) {
+		return nil, ErrWrongTenant
+	}
+	var team models.Team
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return db.
+			Where("company_id = ? AND LOWER(name) = LOWER(?)", companyID, name).
+			Attrs(models.Team{CompanyID: companyID, Name: name, DepartmentID: departmentID}).
+			FirstOrCreate(&team).Error
+	})
+	return &team, err
+}
+
+func (r *TeamRepository) Update(team *models.Team) error {
+	if !r.tenant.allows(team.CompanyID) {
+		return ErrWrongTenant
+	}
+	return r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Model(team).Select("Name", "DepartmentID").Updates(team).Error
+	})
+}
+
+// Delete removes the team and detaches its members
+func (r *TeamRepository) Delete(id uint) error {
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			var team models.Team
+			if err := r.tenant.where(tx, "company_id").First(&team, id).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.User{}).Where("team_id = ?", id).Update("team_id", nil).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&team).Error
+		})
+	})
+}
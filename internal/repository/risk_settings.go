@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+	"gorm.io/gorm"
+)
+
+type RiskSettingsRepository struct {
+	tenant tenantScope
+}
+
+func NewRiskSettingsRepository() *RiskSettingsRepository {
+	return &RiskSettingsRepository{}
+}
+
+// ForTenant returns a repository that only sees the tenant's risk settings
+func (r *RiskSettingsRepository) ForTenant(ctx context.Context) *RiskSettingsRepository {
+	return &RiskSettingsRepository{tenant: scopeFrom(ctx)}
+}
+
+// GetCurrent returns the company's latest settings, or the defaults (version 0) if it
+// has never saved any
+func (r *RiskSettingsRepository) GetCurrent(companyID uint) (*models.RiskSettings, error) {
+	var settings models.RiskSettings
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Where("company_id = ?", companyID).Order("version DESC").First(&settings).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.DefaultRiskSettings(companyID), nil
+	}
+	return &settings, err
+}
+
+// GetHistory returns every saved version, newest first
+func (r *RiskSettingsRepository) GetHistory(companyID uint) ([]models.RiskSettings, error) {
+	var history []models.RiskSettings
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Where("company_id = ?", companyID).Order("version DESC").Find(&history).Error
+	})
+	return history, err
+}
+
+// Save stores settings as the company's next version
+func (r *RiskSettingsRepository) Save(settings *models.RiskSettings) error {
+	if !r.tenant.allows(settings.CompanyID) {
+		return ErrWrongTenant
+	}
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			var latest int
+			if err := tx.Model(&models.RiskSettings{}).
+				Where("company_id = ?", settings.CompanyID).
+				Select("COALESCE(MAX(version), 0)").
+				Scan(&latest).Error; err != nil {
+				return err
+			}
+
+			settings.ID = 0
+			settings.Version = latest + 1
+			return tx.Create(settings).Error
+		})
+	})
+}
@@ -1,44 +1,215 @@
 package repository
 
 import (
-	"github.com/VinVorteX/NoBurn/internal/database"
+	"context"
+	"errors"
+	"time"
+
 	"github.com/VinVorteX/NoBurn/internal/models"
+	"gorm.io/gorm"
 )
 
-type SurveyRepository struct{}
+var ErrAlreadyParticipated = errors.New("survey already answered")
+
+type SurveyRepository struct {
+	tenant tenantScope
+}
 
 func NewSurveyRepository() *SurveyRepository {
 	return &SurveyRepository{}
 }
 
+// ForTenant returns a repository that only sees the surveys of the tenant in ctx
+func (r *SurveyRepository) ForTenant(ctx context.Context) *SurveyRepository {
+	return &SurveyRepository{tenant: scopeFrom(ctx)}
+}
+
 func (r *SurveyRepository) Create(survey *models.Survey) error {
-	return database.DB.Create(survey).Error
+	if !r.tenant.allows(survey.CompanyID) {
+		return ErrWrongTenant
+	}
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Create(survey).Error
+	})
 }
 
 func (r *SurveyRepository) GetByID(id uint) (*models.Survey, error) {
 	var survey models.Survey
-	err := database.DB.First(&survey, id).Error
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").First(&survey, id).Error
+	})
 	return &survey, err
 }
 
 func (r *SurveyRepository) GetByCompanyID(companyID uint) ([]models.Survey, error) {
 	var surveys []models.Survey
-	err := database.DB.Where("company_id = ? AND is_active = ?", companyID, true).Find(&surveys).Error
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Where("company_id = ? AND is_active = ?", companyID, true).Find(&surveys).Error
+	})
 	return surveys, err
 }
 
 func (r *SurveyRepository) CreateResponse(response *models.SurveyResponse) error {
-	return database.DB.Create(response).Error
+	return r.tenant.run(func(db *gorm.DB) error {
+		if err := r.tenant.owns(db, &models.Survey{}, response.SurveyID); err != nil {
+			return err
+		}
+		return db.Create(response).Error
+	})
 }
 
 func (r *SurveyRepository) GetResponsesByUserID(userID uint) ([]models.SurveyResponse, error) {
 	var responses []models.SurveyResponse
-	err := database.DB.Preload("Survey").Where("user_id = ?", userID).Find(&responses).Error
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.whereIn(db, "survey_id", "surveys").Preload("Survey").Where("user_id = ?", userID).Find(&responses).Error
+	})
+	return responses, err
+}
+
+// GetResponseByID loads a response with its survey
+func (r *SurveyRepository) GetResponseByID(id uint) (*models.SurveyResponse, error) {
+	var response models.SurveyResponse
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.whereIn(db, "survey_id", "surveys").Preload("Survey").First(&response, id).Error
+	})
+	return &response, err
+}
+
+// SaveSentiment replaces a response's per-answer sentiment and aspects and stores
+// its Sentiment, SentimentStatus, Language and BurnoutIndex
+func (r *SurveyRepository) SaveSentiment(response *models.SurveyResponse, answers []models.AnswerSentiment, aspects []models.ResponseAspect) error {
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			updates := map[string]interface{}{
+				"sentiment":        response.Sentiment,
+				"sentiment_status": response.SentimentStatus,
+				"language":         response.Language,
+				"burnout_index":    response.BurnoutIndex,
+			}
+			query := r.tenant.whereIn(tx.Model(&models.SurveyResponse{}), "survey_id", "surveys").Where("id = ?", response.ID)
+
+			// Anonymous rows keep their day-only timestamps, since scoring runs right
+			// after the answer arrives
+			var stamp time.Time
+			var result *gorm.DB
+			if response.UserID == nil {
+				stamp = models.AnonymousTime(time.Now())
+				result = query.UpdateColumns(updates)
+			} else {
+				result = query.Updates(updates)
+			}
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+
+			if err := tx.Where("response_id = ?", response.ID).Delete(&models.AnswerSentiment{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("response_id = ?", response.ID).Delete(&models.ResponseAspect{}).Error; err != nil {
+				return err
+			}
+			for i := range answers {
+				answers[i].ID = 0
+				answers[i].CreatedAt = stamp
+				answers[i].ResponseID = response.ID
+				answers[i].SurveyID = response.SurveyID
+			}
+			if len(answers) > 0 {
+				if err := tx.Create(&answers).Error; err != nil {
+					return err
+				}
+			}
+			for i := range aspects {
+				aspects[i].ID = 0
+				aspects[i].CreatedAt = stamp
+				aspects[i].ResponseID = response.ID
+				aspects[i].SurveyID = response.SurveyID
+			}
+			if len(aspects) == 0 {
+				return nil
+			}
+			return tx.Create(&aspects).Error
+		})
+	})
+}
+
+// GetScoredResponsesByCompanyID returns every scored response to the company's
+// surveys, open or closed, with the aspects found in it
+func (r *SurveyRepository) GetScoredResponsesByCompanyID(companyID uint) ([]models.SurveyResponse, error) {
+	var responses []models.SurveyResponse
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.whereIn(db, "survey_id", "surveys").
+			Preload("Aspects").
+			Where("survey_id IN (SELECT id FROM surveys WHERE company_id = ? AND deleted_at IS NULL)", companyID).
+			Where("sentiment_status = ?", models.SentimentScored).
+			Order("id").Find(&responses).Error
+	})
 	return responses, err
 }
 
 func (r *SurveyRepository) GetResponsesBySurveyID(surveyID uint) ([]models.SurveyResponse, error) {
 	var responses []models.SurveyResponse
-	err := database.DB.Where("survey_id = ?", surveyID).Order("created_at DESC").Find(&responses).Error
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.whereIn(db, "survey_id", "surveys").
+			Preload("AnswerSentiments", func(db *gorm.DB) *gorm.DB { return db.Order("question_index") }).
+			Preload("Aspects", func(db *gorm.DB) *gorm.DB { return db.Order("question_index, aspect") }).
+			Where("survey_id = ?", surveyID).Order("created_at DESC").Find(&responses).Error
+	})
 	return responses, err
-}
\ No newline at end of file
+}
+
+// GetPendingResponses returns up to limit responses created before cutoff that are
+// still waiting for a sentiment score, oldest first. Anonymous responses only carry
+// the day they were created on, so they qualify as soon as the day has started.
+func (r *SurveyRepository) GetPendingResponses(cutoff time.Time, limit int) ([]models.SurveyResponse, error) {
+	var responses []models.SurveyResponse
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.whereIn(db, "survey_id", "surveys").
+			Where("sentiment_status = ? AND created_at < ?", models.SentimentPending, cutoff).
+			Order("created_at, id").Limit(limit).Find(&responses).Error
+	})
+	return responses, err
+}
+
+// CreateAnonymousResponse saves a response that is not linked to a user, refusing
+// a second response with the same participation marker
+func (r *SurveyRepository) CreateAnonymousResponse(participation *models.SurveyParticipation, response *models.SurveyResponse) error {
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := r.tenant.owns(tx, &models.Survey{}, response.SurveyID); err != nil {
+				return err
+			}
+			return createAnonymous(tx, participation, response)
+		})
+	})
+}
+
+// createAnonymous saves an anonymous response with the marker that stops a second
+// one. Both only carry the day they were created on.
+func createAnonymous(tx *gorm.DB, participation *models.SurveyParticipation, response *models.SurveyResponse) error {
+	day := models.AnonymousTime(time.Now())
+	participation.CreatedAt = day
+	response.UserID = nil
+	response.CreatedAt, response.UpdatedAt = day, day
+
+	if err := createParticipation(tx, participation); err != nil {
+		return err
+	}
+	return tx.Create(response).Error
+}
+
+func createParticipation(tx *gorm.DB, participation *models.SurveyParticipation) error {
+	var count int64
+	if err := tx.Model(&models.SurveyParticipation{}).
+		Where("survey_id = ? AND marker = ?", participation.SurveyID, participation.Marker).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrAlreadyParticipated
+	}
+	return tx.Create(participation).Error
+}
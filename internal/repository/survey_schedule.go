@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+	"gorm.io/gorm"
+)
+
+type SurveyScheduleRepository struct {
+	tenant tenantScope
+}
+
+func NewSurveyScheduleRepository() *SurveyScheduleRepository {
+	return &SurveyScheduleRepository{}
+}
+
+// ForTenant returns a repository that only sees the tenant's survey schedules
+func (r *SurveyScheduleRepository) ForTenant(ctx context.Context) *SurveyScheduleRepository {
+	return &SurveyScheduleRepository{tenant: scopeFrom(ctx)}
+}
+
+func (r *SurveyScheduleRepository) Create(schedule *models.SurveySchedule) error {
+	if !r.tenant.allows(schedule.CompanyID) {
+		return ErrWrongTenant
+	}
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Create(schedule).Error
+	})
+}
+
+func (r *SurveyScheduleRepository) GetByID(id uint) (*models.SurveySchedule, error) {
+	var schedule models.SurveySchedule
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Preload("Survey").First(&schedule, id).Error
+	})
+	return &schedule, err
+}
+
+func (r *SurveyScheduleRepository) GetByCompanyID(companyID uint) ([]models.SurveySchedule, error) {
+	var schedules []models.SurveySchedule
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Preload("Survey").Where("company_id = ?", companyID).Order("created_at DESC").Find(&schedules).Error
+	})
+	return schedules, err
+}
+
+// GetActive returns every active schedule across companies, for the scheduler
+func (r *SurveyScheduleRepository) GetActive() ([]models.SurveySchedule, error) {
+	var schedules []models.SurveySchedule
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Where("is_active = ?", true).Find(&schedules).Error
+	})
+	return schedules, err
+}
+
+// OpenInstance closes the schedule's earlier surveys, creates survey as the new instance
+// and records the run, all in one transaction, so a failed run leaves nothing behind
+// and a successful one is never repeated for the same trigger.
+func (r *SurveyScheduleRepository) OpenInstance(schedule *models.SurveySchedule, survey *models.Survey, at time.Time) error {
+	if !r.tenant.allows(schedule.CompanyID) || survey.CompanyID != schedule.CompanyID {
+		return ErrWrongTenant
+	}
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&models.Survey{}).
+				Where("schedule_id = ? AND is_active = ?", schedule.ID, true).
+				Update("is_active", false).Error; err != nil {
+				return err
+			}
+
+			survey.ScheduleID = &schedule.ID
+			if err := tx.Create(survey).Error; err != nil {
+				return err
+			}
+
+			result := r.tenant.where(tx.Model(&models.SurveySchedule{}), "company_id").Where("id = ?", schedule.ID).Update("last_run_at", at)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+			schedule.LastRunAt = &at
+			return nil
+		})
+	})
+}
+
+func (r *SurveyScheduleRepository) Delete(id uint) error {
+	return r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Delete(&models.SurveySchedule{}, id).Error
+	})
+}
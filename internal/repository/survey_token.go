@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+	"gorm.io/gorm"
+)
+
+type SurveyTokenRepository struct {
+	tenant tenantScope
+}
+
+// NewSurveyTokenRepository returns an unscoped repository. Public survey links use it
+// to look a token up, since the company is only known once the token is found.
+func NewSurveyTokenRepository() *SurveyTokenRepository {
+	return &SurveyTokenRepository{}
+}
+
+// ForTenant returns a repository that only sees tokens for the tenant's surveys
+func (r *SurveyTokenRepository) ForTenant(ctx context.Context) *SurveyTokenRepository {
+	return &SurveyTokenRepository{tenant: scopeFrom(ctx)}
+}
+
+func (r *SurveyTokenRepository) Create(token *models.SurveyToken) error {
+	return r.tenant.run(func(db *gorm.DB) error {
+		if err := r.tenant.owns(db, &models.Survey{}, token.SurveyID); err != nil {
+			return err
+		}
+		return db.Create(token).Error
+	})
+}
+
+func (r *SurveyTokenRepository) GetByHash(hash string) (*models.SurveyToken, error) {
+	var token models.SurveyToken
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.whereIn(db, "survey_id", "surveys").Where("token_hash = ?", hash).First(&token).Error
+	})
+	return &token, err
+}
+
+// Redeem marks the token as used and saves the response in one transaction.
+// Any other outstanding invitations for the same user and survey are burned as well.
+// participation is only set for anonymous surveys, whose tokens are deleted instead:
+// a used_at next to the user ID would date the anonymous answer.
+func (r *SurveyTokenRepository) Redeem(token *models.SurveyToken, response *models.SurveyResponse, participation *models.SurveyParticipation) error {
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := r.tenant.owns(tx, &models.Survey{}, token.SurveyID); err != nil {
+				return err
+			}
+			if response.SurveyID != token.SurveyID {
+				return ErrWrongTenant
+			}
+
+			if participation != nil {
+				return redeemAnonymous(tx, token, response, participation)
+			}
+
+			now := time.Now()
+			result := tx.Model(&models.SurveyToken{}).
+				Where("id = ? AND used_at IS NULL", token.ID).
+				Update("used_at", now)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return models.ErrSurveyTokenUsed
+			}
+
+			if err := tx.Model(&models.SurveyToken{}).
+				Where("survey_id = ? AND user_id = ? AND used_at IS NULL", token.SurveyID, token.UserID).
+				Update("used_at", now).Error; err != nil {
+				return err
+			}
+
+			return tx.Create(response).Error
+		})
+	})
+}
+
+func redeemAnonymous(tx *gorm.DB, token *models.SurveyToken, response *models.SurveyResponse, participation *models.SurveyParticipation) error {
+	result := tx.Where("id = ? AND used_at IS NULL", token.ID).Delete(&models.SurveyToken{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrSurveyTokenUsed
+	}
+
+	if err := tx.Where("survey_id = ? AND user_id = ?", token.SurveyID, token.UserID).Delete(&models.SurveyToken{}).Error; err != nil {
+		return err
+	}
+	return createAnonymous(tx, participation, response)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/VinVorteX/NoBurn/internal/database"
+	"gorm.io/gorm"
+)
+
+// ErrWrongTenant is returned when a scoped repository is asked to write another company's rows
+var ErrWrongTenant = errors.New("record belongs to another company")
+
+type tenantKey struct{}
+
+// WithTenant records the company the request acts for
+func WithTenant(ctx context.Context, companyID uint) context.Context {
+	return context.WithValue(ctx, tenantKey{}, companyID)
+}
+
+// TenantFromContext returns the company set by WithTenant
+func TenantFromContext(ctx context.Context) (uint, bool) {
+	companyID, ok := ctx.Value(tenantKey{}).(uint)
+	return companyID, ok
+}
+
+// tenantScope restricts queries to one company. The zero value is unscoped and is
+// only used by the plain constructors, which background jobs use across companies.
+type tenantScope struct {
+	scoped    bool
+	companyID uint
+}
+
+// scopeFrom scopes to the tenant in ctx. A context without a tenant is scoped to
+// company 0, which matches nothing, so a missing tenant fails closed.
+func scopeFrom(ctx context.Context) tenantScope {
+	companyID, _ := TenantFromContext(ctx)
+	return tenantScope{scoped: true, companyID: companyID}
+}
+
+// run executes fn against the database. Scoped repositories run it in a transaction
+// carrying app.company_id, so row-level security backs up the explicit filters.
+func (s tenantScope) run(fn func(db *gorm.DB) error) error {
+	if !s.scoped {
+		return fn(database.DB)
+	}
+	return database.WithCompany(s.companyID, fn)
+}
+
+// where filters on a company_id column
+func (s tenantScope) where(db *gorm.DB, column string) *gorm.DB {
+	if !s.scoped {
+		return db
+	}
+	return db.Where(column+" = ?", s.companyID)
+}
+
+// whereIn filters on a column referencing table's id, for tables without company_id
+func (s tenantScope) whereIn(db *gorm.DB, column, table string) *gorm.DB {
+	if !s.scoped {
+		return db
+	}
+	return db.Where(column+" IN (SELECT id FROM "+table+" WHERE company_id = ?)", s.companyID)
+}
+
+func (s tenantScope) allows(companyID uint) bool {
+	return !s.scoped || s.companyID == companyID
+}
+
+// owns fails with ErrWrongTenant unless the model's row with id belongs to the tenant.
+// It guards writes to tables that reference a tenant's rows instead of carrying company_id.
+func (s tenantScope) owns(db *gorm.DB, model interface{}, id uint) error {
+	if !s.scoped {
+		return nil
+	}
+	var count int64
+	if err := s.where(db.Model(model), "company_id").Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrWrongTenant
+	}
+	return nil
+}
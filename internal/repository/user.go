@@ -1,38 +1,99 @@
 package repository
 
 import (
-	"github.com/VinVorteX/NoBurn/internal/database"
+	"context"
+
 	"github.com/VinVorteX/NoBurn/internal/models"
+	"gorm.io/gorm"
 )
 
-type UserRepository struct{}
+type UserRepository struct {
+	tenant tenantScope
+}
 
 func NewUserRepository() *UserRepository {
 	return &UserRepository{}
 }
 
+// ForTenant returns a repository that only sees the tenant's employees
+func (r *UserRepository) ForTenant(ctx context.Context) *UserRepository {
+	return &UserRepository{tenant: scopeFrom(ctx)}
+}
+
 func (r *UserRepository) Create(user *models.User) error {
-	return database.DB.Create(user).Error
+	if !r.tenant.allows(user.CompanyID) {
+		return ErrWrongTenant
+	}
+	return r.tenant.run(func(db *gorm.DB) error {
+		return db.Create(user).Error
+	})
 }
 
 func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 	var user models.User
-	err := database.DB.Where("email = ?", email).First(&user).Error
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Where("email = ?", email).First(&user).Error
+	})
 	return &user, err
 }
 
 func (r *UserRepository) GetByID(id uint) (*models.User, error) {
 	var user models.User
-	err := database.DB.Preload("Company").First(&user, id).Error
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Preload("Company").First(&user, id).Error
+	})
 	return &user, err
 }
 
 func (r *UserRepository) GetByCompanyID(companyID uint) ([]models.User, error) {
 	var users []models.User
-	err := database.DB.Where("company_id = ?", companyID).Find(&users).Error
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Preload("Department").Preload("Team").Where("company_id = ?", companyID).Find(&users).Error
+	})
+	return users, err
+}
+
+func (r *UserRepository) Update(user *models.User) error {
+	if !r.tenant.allows(user.CompanyID) {
+		return ErrWrongTenant
+	}
+	return r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Model(user).Select("Name", "Role", "DepartmentID", "TeamID", "ManagerID").Updates(user).Error
+	})
+}
+
+func (r *UserRepository) GetDirectReports(managerID uint) ([]models.User, error) {
+	var users []models.User
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Where("manager_id = ?", managerID).Find(&users).Error
+	})
 	return users, err
 }
 
+// GetReportIDs returns the IDs of everyone below managerID in the reporting chain
+func (r *UserRepository) GetReportIDs(managerID uint) ([]uint, error) {
+	companyFilter := ""
+	args := []interface{}{managerID}
+	if r.tenant.scoped {
+		companyFilter = " AND company_id = ?"
+		args = append(args, r.tenant.companyID)
+	}
+
+	var ids []uint
+	err := r.tenant.run(func(db *gorm.DB) error {
+		return db.Raw(`
+		WITH RECURSIVE reports AS (
+			SELECT id FROM users WHERE manager_id = ? AND deleted_at IS NULL`+companyFilter+`
+			UNION
+			SELECT u.id FROM users u JOIN reports r ON u.manager_id = r.id WHERE u.deleted_at IS NULL
+		)
+		SELECT id FROM reports`, args...).Scan(&ids).Error
+	})
+	return ids, err
+}
+
 func (r *UserRepository) Delete(id uint) error {
-	return database.DB.Delete(&models.User{}, id).Error
-}
\ No newline at end of file
+	return r.tenant.run(func(db *gorm.DB) error {
+		return r.tenant.where(db, "company_id").Delete(&models.User{}, id).Error
+	})
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/VinVorteX/NoBurn/internal/database"
+	"github.com/VinVorteX/NoBurn/internal/models"
+)
+
+func TestUpdateEmployeeKeepsOmittedFields(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+
+	department := models.Department{CompanyID: f.employeeA.CompanyID, Name: "Engineering"}
+	database.DB.Create(&department)
+	team := models.Team{CompanyID: f.employeeA.CompanyID, DepartmentID: &department.ID, Name: "Platform"}
+	database.DB.Create(&team)
+	manager := models.User{Email: "lead@a.test", Password: "x", Name: "Lead A", Role: models.RoleEmployee, CompanyID: f.employeeA.CompanyID}
+	database.DB.Create(&manager)
+	database.DB.Model(&f.employeeA).Updates(map[string]interface{}{
+		"department_id": department.ID,
+		"team_id":       team.ID,
+		"manager_id":    manager.ID,
+	})
+
+	path := fmt.Sprintf("/api/employees/%d", f.employeeA.ID)
+	tests := []struct {
+		name        string
+		body        string
+		wantName    string
+		wantManager bool
+	}{
+		{"rename only", `{"name": "Renamed"}`, "Renamed", true},
+		{"explicit null clears", `{"manager_id": null}`, "Renamed", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := request(t, handler, http.MethodPut, path, f.tokenA, tt.body)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+
+			var stored models.User
+			database.DB.First(&stored, f.employeeA.ID)
+			if stored.Name != tt.wantName {
+				t.Errorf("Expected name %q, got %q", tt.wantName, stored.Name)
+			}
+			if stored.DepartmentID == nil || *stored.DepartmentID != department.ID {
+				t.Errorf("Expected department %d to be kept, got %v", department.ID, stored.DepartmentID)
+			}
+			if stored.TeamID == nil || *stored.TeamID != team.ID {
+				t.Errorf("Expected team %d to be kept, got %v", team.ID, stored.TeamID)
+			}
+			if (stored.ManagerID != nil) != tt.wantManager {
+				t.Errorf("Expected manager set = %v, got %v", tt.wantManager, stored.ManagerID)
+			}
+		})
+	}
+}
+
+func TestAssignRejectsTeamOutsideDepartment(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+
+	engineering := models.Department{CompanyID: f.employeeA.CompanyID, Name: "Engineering"}
+	sales := models.Department{CompanyID: f.employeeA.CompanyID, Name: "Sales"}
+	database.DB.Create(&engineering)
+	database.DB.Create(&sales)
+	platform := models.Team{CompanyID: f.employeeA.CompanyID, DepartmentID: &engineering.ID, Name: "Platform"}
+	database.DB.Create(&platform)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+	}{
+		{"create in another department", http.MethodPost, "/api/employees",
+			fmt.Sprintf(`{"email": "new@a.test", "name": "New", "team_id": %d, "department_id": %d}`, platform.ID, sales.ID), http.StatusBadRequest},
+		{"update to another department", http.MethodPut, fmt.Sprintf("/api/employees/%d", f.employeeA.ID),
+			fmt.Sprintf(`{"team_id": %d, "department_id": %d}`, platform.ID, sales.ID), http.StatusBadRequest},
+		{"update to the team's department", http.MethodPut, fmt.Sprintf("/api/employees/%d", f.employeeA.ID),
+			fmt.Sprintf(`{"team_id": %d, "department_id": %d}`, platform.ID, engineering.ID), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := request(t, handler, tt.method, tt.path, f.tokenA, tt.body)
+			if rec.Code != tt.wantCode {
+				t.Errorf("Expected %d, got %d: %s", tt.wantCode, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
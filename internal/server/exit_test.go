@@ -0,0 +1,75 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestOffboardKeepsHistory(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+
+	rec := request(t, handler, http.MethodPost, fmt.Sprintf("/api/employees/%d/offboard", f.employeeA.ID), f.tokenA,
+		`{"exit_type": "voluntary", "reason": "Better offer", "regretted": true}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for offboarding, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = request(t, handler, http.MethodGet, "/api/employees", f.tokenA, "")
+	if strings.Contains(rec.Body.String(), "emp@a.test") {
+		t.Errorf("Expected offboarded employee to leave the active list, got %s", rec.Body.String())
+	}
+
+	rec = request(t, handler, http.MethodGet, "/api/exits", f.tokenA, "")
+	var exits struct {
+		Data []struct {
+			UserID        uint     `json:"user_id"`
+			Regretted     bool     `json:"regretted"`
+			PredictedRisk *float64 `json:"predicted_risk"`
+			User          struct {
+				Email string `json:"email"`
+			} `json:"user"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &exits); err != nil {
+		t.Fatalf("Failed to decode exits: %v", err)
+	}
+	if len(exits.Data) != 1 || exits.Data[0].User.Email != "emp@a.test" || !exits.Data[0].Regretted {
+		t.Fatalf("Expected the offboarded employee's exit, got %s", rec.Body.String())
+	}
+	if exits.Data[0].PredictedRisk == nil || *exits.Data[0].PredictedRisk != 0.9 {
+		t.Errorf("Expected predicted risk 0.9 from before the exit, got %v", exits.Data[0].PredictedRisk)
+	}
+
+	rec = request(t, handler, http.MethodGet, "/api/exits", f.tokenB, "")
+	if strings.Contains(rec.Body.String(), "emp@a.test") {
+		t.Errorf("Expected no exits from another company, got %s", rec.Body.String())
+	}
+
+	// One of the two people employed this year left
+	rec = request(t, handler, http.MethodGet, "/api/dashboard", f.tokenA, "")
+	var dashboard struct {
+		Data struct {
+			AttritionRate float64 `json:"attrition_rate"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &dashboard); err != nil {
+		t.Fatalf("Failed to decode dashboard: %v", err)
+	}
+	if dashboard.Data.AttritionRate != 50 {
+		t.Errorf("Expected attrition rate 50, got %f", dashboard.Data.AttritionRate)
+	}
+}
+
+func TestOffboardRejectsInvalidExitType(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+
+	rec := request(t, handler, http.MethodPost, fmt.Sprintf("/api/employees/%d/offboard", f.employeeA.ID), f.tokenA, `{"exit_type": "retired"}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown exit type, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/database"
+	"github.com/VinVorteX/NoBurn/internal/models"
+	"github.com/VinVorteX/NoBurn/internal/repository"
+	"github.com/VinVorteX/NoBurn/internal/services"
+	"github.com/VinVorteX/NoBurn/internal/services/sentiment"
+)
+
+func TestAttritionRisksListEachEmployeeOnce(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+
+	// A newer calculation supersedes the fixture's 0.9
+	database.DB.Create(&models.AttritionRisk{UserID: f.employeeA.ID, RiskScore: 0.8, CreatedAt: time.Now().Add(time.Minute)})
+	// A backfilled calculation gets a higher ID but is older, so it isn't current
+	database.DB.Create(&models.AttritionRisk{UserID: f.employeeA.ID, RiskScore: 0.7, CreatedAt: time.Now().AddDate(0, 0, -7)})
+
+	rec := request(t, handler, http.MethodGet, "/api/attrition-risks", f.tokenA, "")
+	var risks struct {
+		Data []models.AttritionRisk `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &risks); err != nil {
+		t.Fatalf("Failed to decode risks: %v", err)
+	}
+	if len(risks.Data) != 1 || risks.Data[0].RiskScore != 0.8 {
+		t.Errorf("Expected only the latest risk for the employee, got %s", rec.Body.String())
+	}
+}
+
+func TestRiskHistory(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+
+	database.DB.Create(&models.AttritionRisk{UserID: f.employeeA.ID, RiskScore: 0.5, CreatedAt: time.Now().AddDate(0, 0, -14)})
+
+	rec := request(t, handler, http.MethodGet, fmt.Sprintf("/api/attrition-risks/%d/history?weeks=4", f.employeeA.ID), f.tokenA, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var history struct {
+		Data struct {
+			Current *models.AttritionRisk  `json:"current"`
+			History []models.AttritionRisk `json:"history"`
+			Weekly  []struct {
+				RiskScore float64  `json:"risk_score"`
+				Delta     *float64 `json:"delta"`
+			} `json:"weekly"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &history); err != nil {
+		t.Fatalf("Failed to decode history: %v", err)
+	}
+
+	if len(history.Data.History) != 2 {
+		t.Errorf("Expected 2 calculations, got %d", len(history.Data.History))
+	}
+	if history.Data.Current == nil || history.Data.Current.RiskScore != 0.9 {
+		t.Errorf("Expected current risk 0.9, got %+v", history.Data.Current)
+	}
+
+	weekly := history.Data.Weekly
+	if len(weekly) < 3 || weekly[0].RiskScore != 0.5 || weekly[len(weekly)-1].RiskScore != 0.9 {
+		t.Fatalf("Expected weekly trend from 0.5 to 0.9, got %+v", weekly)
+	}
+
+	rec = request(t, handler, http.MethodGet, fmt.Sprintf("/api/attrition-risks/%d/history?weeks=0", f.employeeA.ID), f.tokenA, "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an out-of-range window, got %d", rec.Code)
+	}
+}
+
+func TestChurnRiskWithoutTrainedModel(t *testing.T) {
+	f := setupTenants(t)
+	analytics := services.NewAnalyticsService(repository.NewUserRepository(), repository.NewSurveyRepository(), sentiment.NewMLService(sentiment.NewRegistry()))
+
+	risk, err := analytics.AnalyzeUserChurnRisk(f.employeeA.ID)
+	if err != nil {
+		t.Fatalf("Expected the default weights without a trained model, got %v", err)
+	}
+	if risk.ModelVersion != nil {
+		t.Errorf("Expected no model version, got %d", *risk.ModelVersion)
+	}
+
+	// A broken lookup is an error, not a silent fall back to the defaults
+	database.DB.Migrator().DropTable(&models.ChurnModel{})
+	if _, err := analytics.AnalyzeUserChurnRisk(f.employeeA.ID); err == nil {
+		t.Error("Expected the model lookup error to be returned")
+	}
+}
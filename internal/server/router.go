@@ -8,12 +8,14 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/VinVorteX/NoBurn/internal/handlers/auth"
 	"github.com/VinVorteX/NoBurn/internal/handlers/employee"
+	"github.com/VinVorteX/NoBurn/internal/handlers/org"
 	"github.com/VinVorteX/NoBurn/internal/handlers/settings"
 	"github.com/VinVorteX/NoBurn/internal/handlers/survey"
 	"github.com/VinVorteX/NoBurn/internal/handlers/analytics"
 	"github.com/VinVorteX/NoBurn/internal/handlers/health"
 	"github.com/VinVorteX/NoBurn/internal/handlers/webhook"
 	middlewareAuth "github.com/VinVorteX/NoBurn/internal/middleware/auth"
+	"github.com/VinVorteX/NoBurn/internal/policy"
 )
 
 func New() http.Handler {
@@ -57,27 +59,66 @@ func New() http.Handler {
 	// Protected routes
 	r.Route("/api", func(r chi.Router) {
 		r.Use(middlewareAuth.RequireAuth)
+		r.Use(middlewareAuth.LoadUser)
+
+		manageEmployees := middlewareAuth.RequirePermission(policy.ManageEmployees)
+		viewEmployees := middlewareAuth.RequirePermission(policy.ViewEmployees)
+		manageSurveys := middlewareAuth.RequirePermission(policy.ManageSurveys)
+		viewSurveyResults := middlewareAuth.RequirePermission(policy.ViewSurveyResults)
+		viewAnalytics := middlewareAuth.RequirePermission(policy.ViewAnalytics)
+		manageSettings := middlewareAuth.RequirePermission(policy.ManageSettings)
 		
 		// Employee routes
-		r.Post("/employees", employee.AddEmployee)
-		r.Post("/employees/bulk", employee.BulkUploadEmployees)
-		r.Get("/employees", employee.GetEmployees)
-		r.Delete("/employees/{employeeID}", employee.DeleteEmployee)
+		r.With(manageEmployees).Post("/employees", employee.AddEmployee)
+		r.With(manageEmployees).Post("/employees/bulk", employee.BulkUploadEmployees)
+		r.With(viewEmployees).Get("/employees", employee.GetEmployees)
+		r.With(manageEmployees).Put("/employees/{employeeID}", employee.UpdateEmployee)
+		r.With(manageEmployees).Delete("/employees/{employeeID}", employee.DeleteEmployee)
+		r.With(viewEmployees).Get("/employees/{employeeID}/reports", employee.GetDirectReports)
+		r.With(manageEmployees).Post("/employees/{employeeID}/offboard", employee.OffboardEmployee)
+		r.With(viewAnalytics).Get("/exits", employee.GetExits)
+
+		// Org structure routes
+		r.Get("/departments", org.GetDepartments)
+		r.With(manageEmployees).Post("/departments", org.CreateDepartment)
+		r.With(manageEmployees).Put("/departments/{departmentID}", org.UpdateDepartment)
+		r.With(manageEmployees).Delete("/departments/{departmentID}", org.DeleteDepartment)
+		r.Get("/teams", org.GetTeams)
+		r.With(manageEmployees).Post("/teams", org.CreateTeam)
+		r.With(manageEmployees).Put("/teams/{teamID}", org.UpdateTeam)
+		r.With(manageEmployees).Delete("/teams/{teamID}", org.DeleteTeam)
 		
 		// Survey routes
-		r.Post("/surveys", survey.CreateSurvey)
+		r.With(manageSurveys).Post("/surveys", survey.CreateSurvey)
 		r.Get("/surveys", survey.GetSurveys)
-		r.Get("/surveys/{surveyID}/responses", survey.GetSurveyResponses)
+		r.With(viewSurveyResults).Get("/surveys/{surveyID}/responses", survey.GetSurveyResponses)
+		r.With(viewSurveyResults).Get("/surveys/{surveyID}/summary", survey.GetSurveySummary)
 		r.Post("/surveys/responses", survey.SubmitResponse)
+		r.With(manageSurveys).Post("/survey-schedules", survey.CreateSurveySchedule)
+		r.With(manageSurveys).Get("/survey-schedules", survey.GetSurveySchedules)
+		r.With(manageSurveys).Delete("/survey-schedules/{scheduleID}", survey.DeleteSurveySchedule)
 		
-		// Analytics routes
-		r.Get("/dashboard", analytics.GetDashboard)
-		r.Get("/attrition-risks", analytics.GetAttritionRisks)
-		r.Get("/retention-suggestions/{userID}", analytics.GetRetentionSuggestions)
+		// Analytics routes, scoped to a people manager's reports
+		r.With(viewAnalytics).Get("/dashboard", analytics.GetDashboard)
+		r.With(viewAnalytics).Get("/attrition-risks", analytics.GetAttritionRisks)
+		r.With(viewAnalytics).Get("/attrition-risks/{userID}/history", analytics.GetRiskHistory)
+		r.With(viewAnalytics).Get("/alerts", analytics.GetAlerts)
+		r.With(viewAnalytics).Get("/retention-suggestions/{userID}", analytics.GetRetentionSuggestions)
+		r.With(manageSettings).Get("/churn-models", analytics.GetChurnModels)
+		r.With(manageSettings).Post("/churn-models/train", analytics.TrainChurnModel)
 		
 		// Settings routes
-		r.Get("/settings/smtp", settings.GetSMTPSettings)
-		r.Put("/settings/smtp", settings.UpdateSMTPSettings)
+		r.With(manageSettings).Get("/settings/smtp", settings.GetSMTPSettings)
+		r.With(manageSettings).Put("/settings/smtp", settings.UpdateSMTPSettings)
+		r.Get("/settings/company", settings.GetCompanySettings)
+		r.With(manageSettings).Put("/settings/company", settings.UpdateCompanySettings)
+		r.With(manageSettings).Get("/settings/risk", settings.GetRiskSettings)
+		r.With(manageSettings).Put("/settings/risk", settings.UpdateRiskSettings)
+		r.With(manageSettings).Get("/settings/risk/history", settings.GetRiskSettingsHistory)
+		r.With(manageSettings).Get("/settings/alert-rules", settings.GetAlertRules)
+		r.With(manageSettings).Post("/settings/alert-rules", settings.CreateAlertRule)
+		r.With(manageSettings).Put("/settings/alert-rules/{ruleID}", settings.UpdateAlertRule)
+		r.With(manageSettings).Delete("/settings/alert-rules/{ruleID}", settings.DeleteAlertRule)
 	})
 
 	return r
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+)
+
+func TestRiskSettingsAreVersionedAndApplied(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+
+	rec := request(t, handler, http.MethodGet, "/api/dashboard", f.tokenA, "")
+	if atRisk := dashboardAtRisk(t, rec.Body.Bytes()); atRisk != 1 {
+		t.Fatalf("Expected 1 at-risk employee with the default threshold, got %d", atRisk)
+	}
+
+	rec = request(t, handler, http.MethodPut, "/api/settings/risk", f.tokenA, `{"high_risk_threshold": 0.95}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for valid settings, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = request(t, handler, http.MethodGet, "/api/dashboard", f.tokenA, "")
+	if atRisk := dashboardAtRisk(t, rec.Body.Bytes()); atRisk != 0 {
+		t.Errorf("Expected no at-risk employees above 0.95, got %d", atRisk)
+	}
+
+	rec = request(t, handler, http.MethodPut, "/api/settings/risk", f.tokenA, `{"sentiment_weight": 0.9}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for weights not summing to 1, got %d", rec.Code)
+	}
+
+	request(t, handler, http.MethodPut, "/api/settings/risk", f.tokenA, `{"watch_threshold": 0.4}`)
+	rec = request(t, handler, http.MethodGet, "/api/settings/risk/history", f.tokenA, "")
+	var history struct {
+		Data []struct {
+			Version           int     `json:"version"`
+			HighRiskThreshold float64 `json:"high_risk_threshold"`
+			WatchThreshold    float64 `json:"watch_threshold"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &history); err != nil {
+		t.Fatalf("Failed to decode history: %v", err)
+	}
+	if len(history.Data) != 2 || history.Data[0].Version != 2 {
+		t.Fatalf("Expected versions 2 and 1, got %s", rec.Body.String())
+	}
+	if latest := history.Data[0]; latest.HighRiskThreshold != 0.95 || latest.WatchThreshold != 0.4 {
+		t.Errorf("Expected the new version to keep earlier changes, got %+v", latest)
+	}
+
+	// Another company still sees the defaults
+	rec = request(t, handler, http.MethodGet, "/api/settings/risk", f.tokenB, "")
+	var current struct {
+		Data struct {
+			Version           int     `json:"version"`
+			HighRiskThreshold float64 `json:"high_risk_threshold"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &current); err != nil {
+		t.Fatalf("Failed to decode risk settings: %v", err)
+	}
+	if current.Data.Version != 0 || current.Data.HighRiskThreshold != 0.7 {
+		t.Errorf("Expected defaults for another company, got %s", rec.Body.String())
+	}
+}
+
+func TestCompanySentimentProviders(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+
+	rec := request(t, handler, http.MethodPut, "/api/settings/company", f.tokenA, `{"sentiment_providers": {"default": ["unknown"]}}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unregistered provider, got %d", rec.Code)
+	}
+
+	rec = request(t, handler, http.MethodPut, "/api/settings/company", f.tokenA, `{"sentiment_providers": {"hi": ["huggingface", "lexicon"]}}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for registered providers, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = request(t, handler, http.MethodGet, "/api/settings/company", f.tokenA, "")
+	var settings struct {
+		Data struct {
+			SentimentProviders map[string][]string `json:"sentiment_providers"`
+			AvailableProviders []string            `json:"available_providers"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &settings); err != nil {
+		t.Fatalf("Failed to decode settings: %v", err)
+	}
+	if chain := settings.Data.SentimentProviders["hi"]; len(chain) != 2 || chain[0] != "huggingface" {
+		t.Errorf("Expected the saved Hindi chain, got %s", rec.Body.String())
+	}
+	if len(settings.Data.AvailableProviders) == 0 {
+		t.Errorf("Expected available providers to be listed, got %s", rec.Body.String())
+	}
+
+	request(t, handler, http.MethodPut, "/api/settings/company", f.tokenA, `{"sentiment_providers": {}}`)
+	rec = request(t, handler, http.MethodGet, "/api/settings/company", f.tokenA, "")
+	settings.Data.SentimentProviders = nil
+	if err := json.Unmarshal(rec.Body.Bytes(), &settings); err != nil {
+		t.Fatalf("Failed to decode company settings: %v", err)
+	}
+	if len(settings.Data.SentimentProviders) != 0 {
+		t.Errorf("Expected an empty object to clear the override, got %s", rec.Body.String())
+	}
+}
+
+func TestAlertRuleKeepsExplicitZeroValues(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+
+	rec := request(t, handler, http.MethodPost, "/api/settings/alert-rules", f.tokenA,
+		`{"name": "Paused", "type": "threshold", "threshold": 0.8, "cooldown_days": 0, "is_active": false}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = request(t, handler, http.MethodGet, "/api/settings/alert-rules", f.tokenA, "")
+	var rules struct {
+		Data struct {
+			Rules []models.AlertRule `json:"rules"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &rules); err != nil {
+		t.Fatalf("Failed to decode alert rules: %v", err)
+	}
+	if len(rules.Data.Rules) != 1 {
+		t.Fatalf("Expected the created rule, got %s", rec.Body.String())
+	}
+	if rule := rules.Data.Rules[0]; rule.IsActive || rule.CooldownDays != 0 {
+		t.Errorf("Expected an inactive rule without cooldown, got is_active=%v cooldown_days=%d", rule.IsActive, rule.CooldownDays)
+	}
+
+	// Another company can neither see nor change the rule
+	rec = request(t, handler, http.MethodGet, "/api/settings/alert-rules", f.tokenB, "")
+	if strings.Contains(rec.Body.String(), "Paused") {
+		t.Errorf("Expected no rules from another company, got %s", rec.Body.String())
+	}
+	rec = request(t, handler, http.MethodDelete, fmt.Sprintf("/api/settings/alert-rules/%d", rules.Data.Rules[0].ID), f.tokenB, "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for another company's rule, got %d", rec.Code)
+	}
+}
+
+func dashboardAtRisk(t *testing.T, body []byte) int {
+	t.Helper()
+	var dashboard struct {
+		Data struct {
+			AtRiskEmployees int `json:"at_risk_employees"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &dashboard); err != nil {
+		t.Fatalf("Failed to decode dashboard: %v", err)
+	}
+	return dashboard.Data.AtRiskEmployees
+}
@@ -0,0 +1,298 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/config"
+	"github.com/VinVorteX/NoBurn/internal/database"
+	"github.com/VinVorteX/NoBurn/internal/models"
+	"github.com/VinVorteX/NoBurn/internal/repository"
+	"github.com/VinVorteX/NoBurn/internal/services"
+	"github.com/VinVorteX/NoBurn/internal/services/sentiment"
+	"github.com/VinVorteX/NoBurn/internal/utils"
+)
+
+func TestSubmittedResponsesAreScoredLater(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+	token, _ := utils.GenerateToken(f.employeeA.ID, f.employeeA.Email)
+
+	rec := request(t, handler, http.MethodPost, "/api/surveys/responses", token, fmt.Sprintf(`{"survey_id": %d, "responses": ["I am very happy with my team"]}`, f.surveyA.ID))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var submitted struct {
+		Data models.SurveyResponse `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &submitted); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if submitted.Data.SentimentStatus != models.SentimentPending || submitted.Data.Sentiment != 0 {
+		t.Fatalf("Expected an unscored pending response, got %+v", submitted.Data)
+	}
+
+	// Answering in the app redeems no invitation, so the response rate is unchanged
+	var activity models.UserActivity
+	database.DB.First(&activity, "user_id = ?", f.employeeA.ID)
+	if activity.InvitationsAnswered != 0 || activity.LastResponseAt == nil {
+		t.Errorf("Expected a response time without an answered invitation, got %+v", activity)
+	}
+
+	// Pending responses don't drag the dashboard average towards neutral
+	rec = request(t, handler, http.MethodGet, "/api/dashboard", f.tokenA, "")
+	var dashboard struct {
+		Data struct {
+			AvgSentiment float64 `json:"avg_sentiment"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &dashboard); err != nil {
+		t.Fatalf("Failed to decode dashboard: %v", err)
+	}
+	if dashboard.Data.AvgSentiment != -0.5 {
+		t.Errorf("Expected only the scored response in the average, got %f", dashboard.Data.AvgSentiment)
+	}
+
+	// What the worker does for survey:process
+	surveyService := services.NewSurveyService(sentiment.NewMLService(sentiment.NewRegistry()))
+	scored, err := surveyService.ScoreResponse(submitted.Data.ID, "")
+	if err != nil {
+		t.Fatalf("Expected scoring to succeed, got %v", err)
+	}
+	if scored.Survey.CompanyID != f.surveyA.CompanyID {
+		t.Errorf("Expected the survey's company %d for the churn task, got %d", f.surveyA.CompanyID, scored.Survey.CompanyID)
+	}
+
+	var stored models.SurveyResponse
+	database.DB.First(&stored, submitted.Data.ID)
+	if stored.SentimentStatus != models.SentimentScored || stored.Sentiment <= 0 {
+		t.Errorf("Expected a stored positive score, got %+v", stored)
+	}
+
+	rec = request(t, handler, http.MethodGet, fmt.Sprintf("/api/surveys/%d/responses", f.surveyA.ID), f.tokenA, "")
+	var results struct {
+		Data struct {
+			Responses []struct {
+				ID               uint                     `json:"id"`
+				AnswerSentiments []models.AnswerSentiment `json:"answer_sentiments"`
+			} `json:"responses"`
+			QuestionSentiment []services.QuestionSentiment `json:"question_sentiment"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &results); err != nil {
+		t.Fatalf("Failed to decode responses: %v", err)
+	}
+	for _, resp := range results.Data.Responses {
+		if resp.ID != submitted.Data.ID {
+			continue
+		}
+		if len(resp.AnswerSentiments) != 1 || resp.AnswerSentiments[0].Label != models.SentimentPositive || resp.AnswerSentiments[0].Source != sentiment.ProviderLexicon {
+			t.Errorf("Expected one positive lexicon-scored answer, got %+v", resp.AnswerSentiments)
+		}
+	}
+	if qs := results.Data.QuestionSentiment; len(qs) != 1 || qs[0].Answered != 1 || qs[0].Labels[models.SentimentPositive] != 1 {
+		t.Errorf("Expected question-level sentiment for the scored answer, got %+v", qs)
+	}
+}
+
+func TestResponseLanguageIsDetected(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+
+	// Company A scores in English, but the answer is Hinglish
+	id := submitAndScore(t, f, handler, fmt.Sprintf(`{"survey_id": %d, "answers": ["kaam bahut zyada hai, bilkul khush nahi hu"]}`, f.surveyA.ID))
+
+	var stored models.SurveyResponse
+	database.DB.First(&stored, id)
+	if stored.Language != "hi-Latn" {
+		t.Errorf("Expected hi-Latn to be stored, got %q", stored.Language)
+	}
+	if stored.Sentiment >= 0 {
+		t.Errorf("Expected the negated khush to score negative, got %f", stored.Sentiment)
+	}
+}
+
+func TestDashboardRiskFactorsComeFromAspects(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+
+	rec := request(t, handler, http.MethodPut, "/api/settings/company", f.tokenA, `{"aspect_keywords": {"facilities": ["canteen"]}}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for company aspect keywords, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = request(t, handler, http.MethodPut, "/api/settings/company", f.tokenA, `{"aspect_keywords": {"facilities": ["*"]}}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid keyword, got %d", rec.Code)
+	}
+
+	submitAndScore(t, f, handler, fmt.Sprintf(`{"survey_id": %d, "answers": ["great team but the salary is unfair and the canteen is terrible"]}`, f.surveyA.ID))
+
+	rec = request(t, handler, http.MethodGet, "/api/dashboard", f.tokenA, "")
+	var dashboard struct {
+		Data struct {
+			TopRiskFactors []string                 `json:"top_risk_factors"`
+			Aspects        []services.AspectSummary `json:"aspects"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &dashboard); err != nil {
+		t.Fatalf("Failed to decode dashboard: %v", err)
+	}
+	factors := map[string]bool{}
+	for _, factor := range dashboard.Data.TopRiskFactors {
+		factors[factor] = true
+	}
+	if len(factors) != 2 || !factors["Compensation"] || !factors["Facilities"] {
+		t.Errorf("Expected Compensation and Facilities as risk factors, got %v", dashboard.Data.TopRiskFactors)
+	}
+	if len(dashboard.Data.Aspects) != 3 {
+		t.Errorf("Expected culture to be summarized too, got %+v", dashboard.Data.Aspects)
+	}
+}
+
+func TestAnswersAreTaggedWithBurnoutSignals(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+
+	id := submitAndScore(t, f, handler, fmt.Sprintf(`{"survey_id": %d, "answers": ["I am exhausted and so stressed, nothing changes anyway"]}`, f.surveyA.ID))
+
+	var stored models.SurveyResponse
+	database.DB.Preload("AnswerSentiments").First(&stored, id)
+	if stored.BurnoutIndex == nil || *stored.BurnoutIndex < 0.8 {
+		t.Errorf("Expected a high burnout index, got %v", stored.BurnoutIndex)
+	}
+	if len(stored.AnswerSentiments) != 1 {
+		t.Fatalf("Expected one scored answer, got %+v", stored.AnswerSentiments)
+	}
+	answer := stored.AnswerSentiments[0]
+	signals := strings.Join(answer.Signals, ",")
+	if signals != "exhaustion,stress,cynicism" || answer.Disengagement != 0 {
+		t.Errorf("Expected exhaustion, stress and cynicism, got %q (%+v)", signals, answer)
+	}
+}
+
+func TestAnonymousRowsCannotBeJoinedToUser(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+	config.AppConfig.AnonymitySecret = "anonymity-secret"
+
+	survey := models.Survey{
+		CompanyID:     f.surveyA.CompanyID,
+		Title:         "Anonymous pulse",
+		Questions:     models.QuestionList{{Text: "How are you?", Type: models.QuestionTypeText, Required: true}},
+		IsActive:      true,
+		IsAnonymous:   true,
+		AnonymitySalt: "salt",
+	}
+	database.DB.Create(&survey)
+
+	// Two outstanding invitations, as a reminder would leave behind
+	raw, _ := utils.GenerateOpaqueToken()
+	reminder, _ := utils.GenerateOpaqueToken()
+	for _, r := range []string{raw, reminder} {
+		database.DB.Create(&models.SurveyToken{
+			SurveyID:  survey.ID,
+			UserID:    f.employeeA.ID,
+			TokenHash: utils.HashToken(r),
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+	}
+
+	rec := request(t, handler, http.MethodPost, "/api/surveys/responses/public", "", fmt.Sprintf(`{"survey_id": %d, "user_token": %q, "responses": ["I am exhausted"]}`, survey.ID, raw))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var tokens int64
+	database.DB.Model(&models.SurveyToken{}).Where("survey_id = ? AND user_id = ?", survey.ID, f.employeeA.ID).Count(&tokens)
+	if tokens != 0 {
+		t.Errorf("Expected the invitee's tokens to be deleted, found %d", tokens)
+	}
+
+	var response models.SurveyResponse
+	if err := database.DB.Where("survey_id = ?", survey.ID).First(&response).Error; err != nil {
+		t.Fatalf("Expected the response to be stored: %v", err)
+	}
+	if response.UserID != nil {
+		t.Errorf("Expected no user on an anonymous response, got %d", *response.UserID)
+	}
+
+	surveyService := services.NewSurveyService(sentiment.NewMLService(sentiment.NewRegistry()))
+	if _, err := surveyService.ScoreResponse(response.ID, ""); err != nil {
+		t.Fatalf("Expected scoring to succeed, got %v", err)
+	}
+	database.DB.First(&response, response.ID)
+
+	var participation models.SurveyParticipation
+	database.DB.Where("survey_id = ?", survey.ID).First(&participation)
+	var answers []models.AnswerSentiment
+	database.DB.Where("response_id = ?", response.ID).Find(&answers)
+	if len(answers) == 0 {
+		t.Fatal("Expected scored answers")
+	}
+
+	var activity models.UserActivity
+	database.DB.First(&activity, "user_id = ?", f.employeeA.ID)
+	if activity.InvitationsAnswered != 1 || activity.LastResponseAt != nil {
+		t.Errorf("Expected one answered invitation without a response time, got %+v", activity)
+	}
+
+	stamps := map[string]time.Time{
+		"response created_at":      response.CreatedAt,
+		"response updated_at":      response.UpdatedAt,
+		"participation created_at": participation.CreatedAt,
+		"answer created_at":        answers[0].CreatedAt,
+		"activity updated_at":      activity.UpdatedAt,
+	}
+	for name, stamp := range stamps {
+		if !stamp.Equal(models.AnonymousTime(stamp)) {
+			t.Errorf("Expected %s to be coarsened to the day, got %v", name, stamp)
+		}
+	}
+}
+
+func TestPendingResponsesAreFoundForRequeue(t *testing.T) {
+	f := setupTenants(t)
+
+	stale := models.SurveyResponse{SurveyID: f.surveyA.ID, SentimentStatus: models.SentimentPending, CreatedAt: time.Now().Add(-time.Hour)}
+	fresh := models.SurveyResponse{SurveyID: f.surveyA.ID, SentimentStatus: models.SentimentPending}
+	scored := models.SurveyResponse{SurveyID: f.surveyA.ID, SentimentStatus: models.SentimentScored, CreatedAt: time.Now().Add(-time.Hour)}
+	for _, response := range []*models.SurveyResponse{&stale, &fresh, &scored} {
+		database.DB.Create(response)
+	}
+
+	pending, err := repository.NewSurveyRepository().GetPendingResponses(time.Now().Add(-10*time.Minute), 10)
+	if err != nil {
+		t.Fatalf("Expected pending responses, got %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != stale.ID {
+		t.Errorf("Expected only the stale pending response %d, got %+v", stale.ID, pending)
+	}
+}
+
+// submitAndScore submits body as employee A and scores the response the way the
+// worker's survey:process task does, returning the response ID
+func submitAndScore(t *testing.T, f tenantFixture, handler http.Handler, body string) uint {
+	t.Helper()
+	token, _ := utils.GenerateToken(f.employeeA.ID, f.employeeA.Email)
+
+	rec := request(t, handler, http.MethodPost, "/api/surveys/responses", token, body)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var submitted struct {
+		Data models.SurveyResponse `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &submitted); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	surveyService := services.NewSurveyService(sentiment.NewMLService(sentiment.NewRegistry()))
+	if _, err := surveyService.ScoreResponse(submitted.Data.ID, ""); err != nil {
+		t.Fatalf("Expected scoring to succeed, got %v", err)
+	}
+	return submitted.Data.ID
+}
@@ -0,0 +1,203 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/config"
+	"github.com/VinVorteX/NoBurn/internal/database"
+	"github.com/VinVorteX/NoBurn/internal/models"
+	"github.com/VinVorteX/NoBurn/internal/utils"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type tenantFixture struct {
+	surveyA     models.Survey
+	departmentA models.Department
+	teamA       models.Team
+	employeeA   models.User
+	tokenA      string
+	tokenB      string
+	employeeB   models.User
+}
+
+func setupTenants(t *testing.T) tenantFixture {
+	t.Helper()
+
+	config.AppConfig = &config.Config{JwtSecret: "test-secret", JwtExpiresIn: time.Hour}
+
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&models.Company{},
+		&models.Department{},
+		&models.Team{},
+		&models.User{},
+		&models.Survey{},
+		&models.SurveyResponse{},
+		&models.AttritionRisk{},
+		&models.SurveyToken{},
+		&models.SurveyParticipation{},
+		&models.UserActivity{},
+		&models.EmployeeExit{},
+		&models.AlertRule{},
+		&models.Alert{},
+		&models.RiskSettings{},
+		&models.AnswerSentiment{},
+		&models.ResponseAspect{},
+		&models.ChurnModel{},
+	); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	database.DB = db
+
+	var f tenantFixture
+	companyA := models.Company{Name: "A"}
+	companyB := models.Company{Name: "B"}
+	db.Create(&companyA)
+	db.Create(&companyB)
+
+	adminA := models.User{Email: "admin@a.test", Password: "x", Name: "Admin A", Role: models.RoleHRAdmin, CompanyID: companyA.ID}
+	adminB := models.User{Email: "admin@b.test", Password: "x", Name: "Admin B", Role: models.RoleHRAdmin, CompanyID: companyB.ID}
+	f.employeeA = models.User{Email: "emp@a.test", Password: "x", Name: "Employee A", Role: models.RoleEmployee, CompanyID: companyA.ID}
+	f.employeeB = models.User{Email: "emp@b.test", Password: "x", Name: "Employee B", Role: models.RoleEmployee, CompanyID: companyB.ID}
+	for _, u := range []*models.User{&adminA, &adminB, &f.employeeA, &f.employeeB} {
+		if err := db.Create(u).Error; err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	f.surveyA = models.Survey{
+		CompanyID: companyA.ID,
+		Title:     "Pulse",
+		Questions: models.QuestionList{{Text: "How are you?", Type: models.QuestionTypeText, Required: true}},
+		IsActive:  true,
+	}
+	db.Create(&f.surveyA)
+	db.Create(&models.SurveyResponse{
+		SurveyID:  f.surveyA.ID,
+		UserID:    &f.employeeA.ID,
+		Responses: models.StringArray{"Tired"},
+		Answers:   models.AnswerList{{Text: "Tired"}},
+		Sentiment: -0.5,
+	})
+	db.Create(&models.AttritionRisk{UserID: f.employeeA.ID, RiskScore: 0.9, Factors: models.StringArray{"Low sentiment"}})
+
+	f.departmentA = models.Department{CompanyID: companyA.ID, Name: "Engineering"}
+	db.Create(&f.departmentA)
+	f.teamA = models.Team{CompanyID: companyA.ID, Name: "Platform", DepartmentID: &f.departmentA.ID}
+	db.Create(&f.teamA)
+
+	f.tokenA, _ = utils.GenerateToken(adminA.ID, adminA.Email)
+	f.tokenB, _ = utils.GenerateToken(adminB.ID, adminB.Email)
+	return f
+}
+
+func request(t *testing.T, handler http.Handler, method, path, token, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCrossTenantReadsReturnNotFound(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"survey responses", http.MethodGet, fmt.Sprintf("/api/surveys/%d/responses", f.surveyA.ID), ""},
+		{"survey summary", http.MethodGet, fmt.Sprintf("/api/surveys/%d/summary", f.surveyA.ID), ""},
+		{"retention suggestions", http.MethodGet, fmt.Sprintf("/api/retention-suggestions/%d", f.employeeA.ID), ""},
+		{"risk history", http.MethodGet, fmt.Sprintf("/api/attrition-risks/%d/history", f.employeeA.ID), ""},
+		{"direct reports", http.MethodGet, fmt.Sprintf("/api/employees/%d/reports", f.employeeA.ID), ""},
+		{"submit response", http.MethodPost, "/api/surveys/responses", fmt.Sprintf(`{"survey_id": %d, "responses": ["hi"]}`, f.surveyA.ID)},
+		{"offboard", http.MethodPost, fmt.Sprintf("/api/employees/%d/offboard", f.employeeA.ID), `{"exit_type": "voluntary"}`},
+		{"department rename", http.MethodPut, fmt.Sprintf("/api/departments/%d", f.departmentA.ID), `{"name": "Taken"}`},
+		{"department delete", http.MethodDelete, fmt.Sprintf("/api/departments/%d", f.departmentA.ID), ""},
+		{"team delete", http.MethodDelete, fmt.Sprintf("/api/teams/%d", f.teamA.ID), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := request(t, handler, tt.method, tt.path, f.tokenB, tt.body)
+			if rec.Code != http.StatusNotFound {
+				t.Errorf("Expected 404 for another company's %s, got %d: %s", tt.name, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestSameTenantReadsSucceed(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+
+	rec := request(t, handler, http.MethodGet, fmt.Sprintf("/api/surveys/%d/responses", f.surveyA.ID), f.tokenA, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for own survey responses, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "Tired") {
+		t.Errorf("Expected own survey responses in body, got %s", rec.Body.String())
+	}
+}
+
+func TestCrossTenantListsAreEmpty(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+
+	rec := request(t, handler, http.MethodGet, "/api/attrition-risks", f.tokenB, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "Employee A") {
+		t.Errorf("Expected no risks from another company, got %s", rec.Body.String())
+	}
+
+	rec = request(t, handler, http.MethodGet, "/api/employees", f.tokenB, "")
+	if strings.Contains(rec.Body.String(), "emp@a.test") {
+		t.Errorf("Expected no employees from another company, got %s", rec.Body.String())
+	}
+
+	rec = request(t, handler, http.MethodGet, "/api/teams", f.tokenB, "")
+	if strings.Contains(rec.Body.String(), "Platform") {
+		t.Errorf("Expected no teams from another company, got %s", rec.Body.String())
+	}
+}
+
+func TestPublicSurveyScopedToInviteeCompany(t *testing.T) {
+	f := setupTenants(t)
+	handler := New()
+
+	// A token pointing at company A's survey but issued to company B's employee
+	raw, _ := utils.GenerateOpaqueToken()
+	database.DB.Create(&models.SurveyToken{
+		SurveyID:  f.surveyA.ID,
+		UserID:    f.employeeB.ID,
+		TokenHash: utils.HashToken(raw),
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+
+	rec := request(t, handler, http.MethodGet, fmt.Sprintf("/api/surveys/%d/public?token=%s", f.surveyA.ID, raw), "", "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a survey outside the invitee's company, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -1,23 +1,25 @@
 package services
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"strings"
-	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/services/mlclient"
+	"github.com/VinVorteX/NoBurn/internal/services/sentiment"
 )
 
 type AIService struct {
-	hfToken string
-	client  *http.Client
+	config sentiment.MLConfig
+	client *mlclient.Client
 }
 
-func NewAIService(hfToken string) *AIService {
+// NewAIService shares client with the Hugging Face sentiment analyzer
+func NewAIService(config sentiment.MLConfig, client *mlclient.Client) *AIService {
 	return &AIService{
-		hfToken: hfToken,
-		client:  &http.Client{Timeout: 30 * time.Second},
+		config: config,
+		client: client,
 	}
 }
 
@@ -28,11 +30,12 @@ type RetentionSuggestion struct {
 }
 
 // Generate AI-powered retention suggestions using LLM
-func (s *AIService) GenerateRetentionSuggestions(riskFactors []string, language string) ([]RetentionSuggestion, error) {
+func (s *AIService) GenerateRetentionSuggestions(companyID uint, riskFactors []string, language string) ([]RetentionSuggestion, error) {
 	prompt := s.buildPrompt(riskFactors, language)
 	
-	// Try Hugging Face API first
-	suggestions, err := s.callHuggingFace(prompt, language)
+	// Try Hugging Face API first; an open breaker or exhausted limit fails fast
+	ctx := mlclient.WithCompany(context.Background(), companyID)
+	suggestions, err := s.callHuggingFace(ctx, prompt)
 	if err == nil {
 		return suggestions, nil
 	}
@@ -64,7 +67,7 @@ func (s *AIService) buildPrompt(riskFactors []string, language string) string {
 	return prompts[language]
 }
 
-func (s *AIService) callHuggingFace(prompt, language string) ([]RetentionSuggestion, error) {
+func (s *AIService) callHuggingFace(ctx context.Context, prompt string) ([]RetentionSuggestion, error) {
 	model := "mistralai/Mistral-7B-Instruct-v0.2"
 	
 	reqBody := map[string]interface{}{
@@ -75,26 +78,13 @@ func (s *AIService) callHuggingFace(prompt, language string) ([]RetentionSuggest
 		},
 	}
 	
-	jsonData, _ := json.Marshal(reqBody)
-	req, _ := http.NewRequest("POST",
-		fmt.Sprintf("https://api-inference.huggingface.co/models/%s", model),
-		bytes.NewBuffer(jsonData))
-	
-	req.Header.Set("Authorization", "Bearer "+s.hfToken)
-	req.Header.Set("Content-Type", "application/json")
-	
-	resp, err := s.client.Do(req)
+	body, err := s.client.Post(ctx, s.modelURL(model), s.config.HuggingFaceToken, reqBody)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HF API error: %d", resp.StatusCode)
-	}
 	
 	var result []map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, err
 	}
 	
@@ -103,10 +93,17 @@ func (s *AIService) callHuggingFace(prompt, language string) ([]RetentionSuggest
 	}
 	
 	// Parse generated text into suggestions
-	generatedText := result[0]["generated_text"].(string)
+	generatedText, ok := result[0]["generated_text"].(string)
+	if !ok {
+		return nil, fmt.Errorf("no generated text from HF")
+	}
 	return s.parseAISuggestions(generatedText), nil
 }
 
+func (s *AIService) modelURL(model string) string {
+	return strings.TrimRight(s.config.HuggingFaceURL, "/") + "/" + model
+}
+
 func (s *AIService) parseAISuggestions(text string) []RetentionSuggestion {
 	lines := strings.Split(text, "\n")
 	suggestions := []RetentionSuggestion{}
@@ -140,6 +137,7 @@ func (s *AIService) getRuleBasedSuggestions(riskFactors []string, language strin
 			"Reduced activity":            {"Assign engaging projects", "Team collaboration", "Skill development"},
 			"Frequent negative feedback":  {"Recognition program", "Flexible work options", "Mentorship"},
 			"Infrequent system usage":     {"Re-engagement program", "Training sessions", "Team activities"},
+			"Burnout signals in survey answers": {"Review workload and deadlines", "Encourage time off", "Offer wellbeing support"},
 		},
 		"hi": {
 			"Low sentiment scores":        {"व्यक्तिगत बैठक करें", "करियर लक्ष्यों पर चर्चा", "कार्य संबंधी चिंताओं को हल करें"},
@@ -147,6 +145,7 @@ func (s *AIService) getRuleBasedSuggestions(riskFactors []string, language strin
 			"Reduced activity":            {"रोचक प्रोजेक्ट दें", "टीम सहयोग", "कौशल विकास"},
 			"Frequent negative feedback":  {"पहचान कार्यक्रम", "लचीले काम के विकल्प", "मार्गदर्शन"},
 			"Infrequent system usage":     {"पुनः जुड़ाव कार्यक्रम", "प्रशिक्षण सत्र", "टीम गतिविधियाँ"},
+			"Burnout signals in survey answers": {"काम के बोझ और समय-सीमा की समीक्षा", "छुट्टी लेने को प्रोत्साहित करें", "स्वास्थ्य सहायता दें"},
 		},
 		"ta": {
 			"Low sentiment scores":        {"தனிப்பட்ட சந்திப்பு", "தொழில் இலக்குகள் விவாதம்", "வேலை கவலைகள் தீர்க்க"},
@@ -154,6 +153,7 @@ func (s *AIService) getRuleBasedSuggestions(riskFactors []string, language strin
 			"Reduced activity":            {"சுவாரஸ்யமான திட்டங்கள்", "குழு ஒத்துழைப்பு", "திறன் மேம்பாடு"},
 			"Frequent negative feedback":  {"அங்கீகார திட்டம்", "நெகிழ்வான வேலை", "வழிகாட்டுதல்"},
 			"Infrequent system usage":     {"மீண்டும் ஈடுபாடு திட்டம்", "பயிற்சி அமர்வுகள்", "குழு நடவடிக்கைகள்"},
+			"Burnout signals in survey answers": {"வேலைப்பளு மறுஆய்வு", "விடுப்பு எடுக்க ஊக்குவிக்க", "நல்வாழ்வு ஆதரவு"},
 		},
 	}
 	
@@ -184,7 +184,7 @@ func (s *AIService) getRuleBasedSuggestions(riskFactors []string, language strin
 }
 
 func (s *AIService) getPriority(factor string) string {
-	highPriority := []string{"Low sentiment scores", "Frequent negative feedback"}
+	highPriority := []string{"Low sentiment scores", "Frequent negative feedback", "Burnout signals in survey answers"}
 	for _, hp := range highPriority {
 		if factor == hp {
 			return "high"
@@ -194,7 +194,7 @@ func (s *AIService) getPriority(factor string) string {
 }
 
 // Translate text using AI
-func (s *AIService) TranslateText(text, targetLang string) (string, error) {
+func (s *AIService) TranslateText(companyID uint, text, targetLang string) (string, error) {
 	modelMap := map[string]string{
 		"hi": "Helsinki-NLP/opus-mt-en-hi",
 		"ta": "Helsinki-NLP/opus-mt-en-ta",
@@ -209,22 +209,14 @@ func (s *AIService) TranslateText(text, targetLang string) (string, error) {
 		"inputs": text,
 	}
 	
-	jsonData, _ := json.Marshal(reqBody)
-	req, _ := http.NewRequest("POST",
-		fmt.Sprintf("https://api-inference.huggingface.co/models/%s", model),
-		bytes.NewBuffer(jsonData))
-	
-	req.Header.Set("Authorization", "Bearer "+s.hfToken)
-	req.Header.Set("Content-Type", "application/json")
-	
-	resp, err := s.client.Do(req)
+	ctx := mlclient.WithCompany(context.Background(), companyID)
+	body, err := s.client.Post(ctx, s.modelURL(model), s.config.HuggingFaceToken, reqBody)
 	if err != nil {
 		return text, err
 	}
-	defer resp.Body.Close()
 	
 	var result []map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := json.Unmarshal(body, &result); err != nil {
 		return text, err
 	}
 	
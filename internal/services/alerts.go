@@ -0,0 +1,122 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+)
+
+// negativeSentiment is the score below which a response counts as negative
+const negativeSentiment = -0.1
+
+// AlertInput is what alert rules are evaluated against for one employee
+type AlertInput struct {
+	Risk      models.AttritionRisk    // the calculation just made
+	History   []models.AttritionRisk  // earlier calculations
+	Responses []models.SurveyResponse // the employee's survey responses
+}
+
+// EvaluateAlertRules returns an alert for every active rule the input matches, skipping
+// rules that already alerted about the employee within their cooldown. recent holds the
+// employee's earlier alerts.
+func EvaluateAlertRules(rules []models.AlertRule, input AlertInput, recent []models.Alert, now time.Time) []models.Alert {
+	responses := models.ScoredResponses(input.Responses)
+	sort.Slice(responses, func(i, j int) bool { return responses[i].CreatedAt.After(responses[j].CreatedAt) })
+
+	alerts := []models.Alert{}
+	for _, rule := range rules {
+		if !rule.IsActive || firedWithin(rule, recent, now) {
+			continue
+		}
+
+		message, matched := matchRule(rule, input, responses, now)
+		if !matched {
+			continue
+		}
+
+		alert := models.Alert{
+			CompanyID: rule.CompanyID,
+			UserID:    input.Risk.UserID,
+			RuleType:  rule.Type,
+			RuleName:  rule.Name,
+			RiskScore: input.Risk.RiskScore,
+			Message:   message,
+		}
+		if rule.ID != 0 {
+			ruleID := rule.ID
+			alert.RuleID = &ruleID
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts
+}
+
+// matchRule checks one rule; responses must be newest first
+func matchRule(rule models.AlertRule, input AlertInput, responses []models.SurveyResponse, now time.Time) (string, bool) {
+	switch rule.Type {
+	case models.AlertRuleThreshold:
+		if input.Risk.RiskScore >= rule.Threshold {
+			return fmt.Sprintf("Churn risk is %.0f%%", input.Risk.RiskScore*100), true
+		}
+
+	case models.AlertRuleRiskIncrease:
+		// Compare against the lowest score in the window so a steady climb is caught too
+		since := now.AddDate(0, 0, -rule.WindowDays)
+		lowest, found := 0.0, false
+		for _, past := range input.History {
+			if past.ID == input.Risk.ID || past.CreatedAt.Before(since) {
+				continue
+			}
+			if !found || past.RiskScore < lowest {
+				lowest, found = past.RiskScore, true
+			}
+		}
+		if found && input.Risk.RiskScore-lowest >= rule.Threshold {
+			return fmt.Sprintf("Churn risk rose from %.0f%% to %.0f%% within %d days", lowest*100, input.Risk.RiskScore*100, rule.WindowDays), true
+		}
+
+	case models.AlertRuleNegativeStreak:
+		if len(responses) < rule.Count {
+			return "", false
+		}
+		for _, response := range responses[:rule.Count] {
+			if response.Sentiment >= negativeSentiment {
+				return "", false
+			}
+		}
+		return fmt.Sprintf("Last %d survey responses were negative", rule.Count), true
+	}
+	return "", false
+}
+
+// firedWithin reports whether rule alerted within its cooldown. Default rules are not
+// stored, so they are matched by type.
+func firedWithin(rule models.AlertRule, recent []models.Alert, now time.Time) bool {
+	since := now.AddDate(0, 0, -rule.CooldownDays)
+	for _, alert := range recent {
+		sameRule := alert.RuleType == rule.Type
+		if rule.ID != 0 {
+			sameRule = alert.RuleID != nil && *alert.RuleID == rule.ID
+		}
+		if sameRule && !alert.CreatedAt.Before(since) {
+			return true
+		}
+	}
+	return false
+}
+
+// AlertLookback is how far back history and earlier alerts are needed to evaluate rules
+func AlertLookback(rules []models.AlertRule) time.Duration {
+	days := 0
+	for _, rule := range rules {
+		if rule.WindowDays > days {
+			days = rule.WindowDays
+		}
+		if rule.CooldownDays > days {
+			days = rule.CooldownDays
+		}
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+)
+
+func TestEvaluateAlertRules(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+	rules := models.DefaultAlertRules(1, 0.7)
+
+	negative := func(daysAgo int) models.SurveyResponse {
+		return models.SurveyResponse{Sentiment: -0.6, CreatedAt: now.AddDate(0, 0, -daysAgo)}
+	}
+
+	tests := []struct {
+		name     string
+		input    AlertInput
+		recent   []models.Alert
+		expected []string
+	}{
+		{
+			name:     "Above threshold",
+			input:    AlertInput{Risk: models.AttritionRisk{ID: 9, RiskScore: 0.72}},
+			expected: []string{models.AlertRuleThreshold},
+		},
+		{
+			name: "Sharp increase below threshold",
+			input: AlertInput{
+				Risk:    models.AttritionRisk{ID: 9, RiskScore: 0.6},
+				History: []models.AttritionRisk{{ID: 8, RiskScore: 0.2, CreatedAt: now.AddDate(0, 0, -5)}},
+			},
+			expected: []string{models.AlertRuleRiskIncrease},
+		},
+		{
+			name: "Increase outside the window",
+			input: AlertInput{
+				Risk:    models.AttritionRisk{ID: 9, RiskScore: 0.6},
+				History: []models.AttritionRisk{{ID: 8, RiskScore: 0.2, CreatedAt: now.AddDate(0, 0, -10)}},
+			},
+			expected: []string{},
+		},
+		{
+			name: "Negative streak",
+			input: AlertInput{
+				Risk:      models.AttritionRisk{ID: 9, RiskScore: 0.3},
+				Responses: []models.SurveyResponse{negative(1), negative(8), negative(15), {Sentiment: 0.5, CreatedAt: now.AddDate(0, 0, -22)}},
+			},
+			expected: []string{models.AlertRuleNegativeStreak},
+		},
+		{
+			name: "Streak broken by latest response",
+			input: AlertInput{
+				Risk:      models.AttritionRisk{ID: 9, RiskScore: 0.3},
+				Responses: []models.SurveyResponse{negative(8), negative(15), negative(22), {Sentiment: 0.5, CreatedAt: now.AddDate(0, 0, -1)}},
+			},
+			expected: []string{},
+		},
+		{
+			name:     "Already alerted within cooldown",
+			input:    AlertInput{Risk: models.AttritionRisk{ID: 9, RiskScore: 0.72}},
+			recent:   []models.Alert{{RuleType: models.AlertRuleThreshold, CreatedAt: now.AddDate(0, 0, -3)}},
+			expected: []string{},
+		},
+		{
+			name:     "Cooldown expired",
+			input:    AlertInput{Risk: models.AttritionRisk{ID: 9, RiskScore: 0.72}},
+			recent:   []models.Alert{{RuleType: models.AlertRuleThreshold, CreatedAt: now.AddDate(0, 0, -20)}},
+			expected: []string{models.AlertRuleThreshold},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alerts := EvaluateAlertRules(rules, tt.input, tt.recent, now)
+
+			if len(alerts) != len(tt.expected) {
+				t.Fatalf("Expected %d alerts, got %d: %+v", len(tt.expected), len(alerts), alerts)
+			}
+			for i, alert := range alerts {
+				if alert.RuleType != tt.expected[i] {
+					t.Errorf("Expected %s alert, got %s", tt.expected[i], alert.RuleType)
+				}
+				if alert.Message == "" {
+					t.Errorf("Expected alert message for %s", alert.RuleType)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateAlertRulesDeduplicatesConfiguredRules(t *testing.T) {
+	now := time.Now()
+	ruleID := uint(4)
+	rules := []models.AlertRule{
+		{ID: ruleID, Name: "Strict", Type: models.AlertRuleThreshold, Threshold: 0.5, CooldownDays: 7, IsActive: true},
+		{ID: 5, Name: "Disabled", Type: models.AlertRuleThreshold, Threshold: 0.1, CooldownDays: 7, IsActive: false},
+	}
+	input := AlertInput{Risk: models.AttritionRisk{RiskScore: 0.6}}
+
+	alerts := EvaluateAlertRules(rules, input, nil, now)
+	if len(alerts) != 1 || alerts[0].RuleID == nil || *alerts[0].RuleID != ruleID {
+		t.Fatalf("Expected one alert from rule %d, got %+v", ruleID, alerts)
+	}
+
+	// A default-rule alert of the same type does not suppress a configured rule
+	recent := []models.Alert{{RuleType: models.AlertRuleThreshold, CreatedAt: now.Add(-time.Hour)}}
+	if alerts := EvaluateAlertRules(rules, input, recent, now); len(alerts) != 1 {
+		t.Errorf("Expected configured rule to alert, got %d alerts", len(alerts))
+	}
+
+	recent = []models.Alert{{RuleID: &ruleID, RuleType: models.AlertRuleThreshold, CreatedAt: now.Add(-time.Hour)}}
+	if alerts := EvaluateAlertRules(rules, input, recent, now); len(alerts) != 0 {
+		t.Errorf("Expected no repeat alert within cooldown, got %d", len(alerts))
+	}
+}
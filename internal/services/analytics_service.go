@@ -1,60 +1,104 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"time"
+
 	"github.com/VinVorteX/NoBurn/internal/models"
 	"github.com/VinVorteX/NoBurn/internal/repository"
 	"github.com/VinVorteX/NoBurn/internal/services/sentiment"
+	"gorm.io/gorm"
 )
 
 type AnalyticsService struct {
 	userRepo      *repository.UserRepository
 	surveyRepo    *repository.SurveyRepository
+	activityRepo  *repository.UserActivityRepository
+	modelRepo     *repository.ChurnModelRepository
+	settingsRepo  *repository.RiskSettingsRepository
 	mlService     *sentiment.MLService
 	churnPredictor *sentiment.ChurnPredictor
 }
 
-func NewAnalyticsService(userRepo *repository.UserRepository, surveyRepo *repository.SurveyRepository, mlAPIURL string) *AnalyticsService {
+func NewAnalyticsService(userRepo *repository.UserRepository, surveyRepo *repository.SurveyRepository, mlService *sentiment.MLService) *AnalyticsService {
 	return &AnalyticsService{
 		userRepo:      userRepo,
 		surveyRepo:    surveyRepo,
-		mlService:     sentiment.NewMLService(mlAPIURL),
+		activityRepo:  repository.NewUserActivityRepository(),
+		modelRepo:     repository.NewChurnModelRepository(),
+		settingsRepo:  repository.NewRiskSettingsRepository(),
+		mlService:     mlService,
 		churnPredictor: sentiment.NewChurnPredictor(),
 	}
 }
 
+// ForTenant returns a copy of the service whose repositories only see the tenant's rows
+func (s *AnalyticsService) ForTenant(ctx context.Context) *AnalyticsService {
+	scoped := *s
+	scoped.userRepo = s.userRepo.ForTenant(ctx)
+	scoped.surveyRepo = s.surveyRepo.ForTenant(ctx)
+	scoped.activityRepo = s.activityRepo.ForTenant(ctx)
+	scoped.modelRepo = s.modelRepo.ForTenant(ctx)
+	scoped.settingsRepo = s.settingsRepo.ForTenant(ctx)
+	return &scoped
+}
+
 func (s *AnalyticsService) AnalyzeUserChurnRisk(userID uint) (*models.AttritionRisk, error) {
-	// Get user responses
-	responses, err := s.surveyRepo.GetResponsesByUserID(userID)
+	user, features, err := s.userChurnFeatures(userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate features
-	features := s.calculateChurnFeatures(responses)
-	
+	predictor, err := s.predictorFor(user.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a trained model the predictor keeps its configured weights
+	var modelVersion *int
+	active, err := s.modelRepo.GetActive(user.CompanyID)
+	switch {
+	case err == nil:
+		predictor = predictor.WithModel(toSentimentModel(active))
+		modelVersion = &active.Version
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+
 	// Predict risk
-	riskScore := s.churnPredictor.PredictChurnRisk(features)
-	factors := s.churnPredictor.GetRiskFactors(features)
+	prediction := predictor.Predict(features)
+	factors := predictor.GetRiskFactors(features)
+	snapshot := toFeatureSnapshot(features)
 
 	return &models.AttritionRisk{
-		UserID:    userID,
-		RiskScore: riskScore,
-		Factors:   models.StringArray(factors),
+		UserID:        userID,
+		RiskScore:     prediction.RiskScore,
+		Factors:       models.StringArray(factors),
+		Features:      &snapshot,
+		Contributions: toContributionList(prediction.Contributions),
+		ModelVersion:  modelVersion,
 	}, nil
 }
 
 func (s *AnalyticsService) GenerateRetentionSuggestions(userID uint, language string) ([]RetentionSuggestion, error) {
-	responses, err := s.surveyRepo.GetResponsesByUserID(userID)
+	user, features, err := s.userChurnFeatures(userID)
 	if err != nil {
 		return nil, err
 	}
 
-	features := s.calculateChurnFeatures(responses)
-	riskFactors := s.churnPredictor.GetRiskFactors(features)
+	predictor, err := s.predictorFor(user.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	riskFactors := predictor.GetRiskFactors(features)
 	
 	// Use AI service for intelligent suggestions
-	aiService := NewAIService("") // TODO: Get HF token from config
-	return aiService.GenerateRetentionSuggestions(riskFactors, language)
+	aiService, err := SharedAIService()
+	if err != nil {
+		return nil, err
+	}
+	return aiService.GenerateRetentionSuggestions(user.CompanyID, riskFactors, language)
 }
 
 func (s *AnalyticsService) ProcessSurveyResponse(response *models.SurveyResponse, language string) error {
@@ -64,37 +108,98 @@ func (s *AnalyticsService) ProcessSurveyResponse(response *models.SurveyResponse
 		totalText += resp + " "
 	}
 
+	// The provider chain falls back to the lexicon on its own
 	sentimentScore, err := s.mlService.AnalyzeSentiment(totalText, language)
 	if err != nil {
-		// Fallback to rule-based
-		sentimentScore = sentiment.AnalyzeSentiment(totalText, language)
+		return err
 	}
 
 	response.Sentiment = sentimentScore
 	return s.surveyRepo.CreateResponse(response)
 }
 
-func (s *AnalyticsService) calculateChurnFeatures(responses []models.SurveyResponse) sentiment.ChurnFeatures {
-	if len(responses) == 0 {
-		return sentiment.ChurnFeatures{}
+// predictorFor applies the company's risk settings to the predictor
+func (s *AnalyticsService) predictorFor(companyID uint) (*sentiment.ChurnPredictor, error) {
+	settings, err := s.settingsRepo.GetCurrent(companyID)
+	if err != nil {
+		return nil, err
 	}
+	return s.churnPredictor.WithConfig(riskConfigFrom(settings)), nil
+}
 
-	totalSentiment := 0.0
-	negativeCount := 0
+func riskConfigFrom(settings *models.RiskSettings) sentiment.RiskConfig {
+	return sentiment.RiskConfig{
+		Weights:          settings.Weights(),
+		LowSentiment:     settings.LowSentimentCutoff,
+		LowResponseRate:  settings.LowResponseRateCutoff,
+		InactiveDays:     settings.InactiveDaysCutoff,
+		NegativeRatio:    settings.NegativeRatioCutoff,
+		InfrequentLogins: settings.InfrequentLoginsCutoff,
+		HighBurnout:      settings.HighBurnoutCutoff,
+	}
+}
+
+// userChurnFeatures loads everything the churn model needs for one employee
+func (s *AnalyticsService) userChurnFeatures(userID uint) (*models.User, sentiment.ChurnFeatures, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, sentiment.ChurnFeatures{}, err
+	}
+
+	responses, err := s.surveyRepo.GetResponsesByUserID(userID)
+	if err != nil {
+		return nil, sentiment.ChurnFeatures{}, err
+	}
+
+	activity, err := s.activityRepo.Get(userID)
+	if err != nil {
+		return nil, sentiment.ChurnFeatures{}, err
+	}
 
-	for _, resp := range responses {
+	return user, calculateChurnFeatures(responses, activity, user.CreatedAt, time.Now()), nil
+}
+
+// calculateChurnFeatures combines response sentiment and burnout with activity
+// tracking. Employees with no recorded activity at all count as inactive since they
+// joined.
+func calculateChurnFeatures(responses []models.SurveyResponse, activity *models.UserActivity, joinedAt, now time.Time) sentiment.ChurnFeatures {
+	// Pending responses have no sentiment yet, so they count on neither side of the
+	// negative ratio
+	scored := models.ScoredResponses(responses)
+	features := sentiment.ChurnFeatures{
+		ResponseRate:   activity.ResponseRate(),
+		DaysInactive:   models.DaysSince(activity.LastActiveAt(), now),
+		LastLoginDays:  models.DaysSince(activity.LastLoginAt, now),
+		TotalResponses: len(scored),
+	}
+	if activity.LastActiveAt() == nil {
+		features.DaysInactive = models.DaysSince(&joinedAt, now)
+	}
+
+	if len(scored) == 0 {
+		return features
+	}
+
+	totalSentiment := 0.0
+	for _, resp := range scored {
 		totalSentiment += resp.Sentiment
-		if resp.Sentiment < -0.1 {
-			negativeCount++
+		if resp.Sentiment < negativeSentiment {
+			features.NegativeResponses++
 		}
 	}
+	features.AvgSentiment = totalSentiment / float64(len(scored))
 
-	return sentiment.ChurnFeatures{
-		AvgSentiment:      totalSentiment / float64(len(responses)),
-		ResponseRate:      1.0, // TODO: Calculate actual rate
-		DaysInactive:      0,   // TODO: Calculate from last activity
-		NegativeResponses: negativeCount,
-		TotalResponses:    len(responses),
-		LastLoginDays:     0, // TODO: Calculate from user activity
+	// Responses without free text say nothing about burnout, so they don't dilute it
+	burnout, withText := 0.0, 0
+	for _, resp := range scored {
+		if resp.BurnoutIndex != nil {
+			burnout += *resp.BurnoutIndex
+			withText++
+		}
+	}
+	if withText > 0 {
+		features.BurnoutIndex = burnout / float64(withText)
 	}
-}
\ No newline at end of file
+
+	return features
+}
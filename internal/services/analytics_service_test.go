@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+)
+
+func TestCalculateChurnFeatures(t *testing.T) {
+	now := time.Now()
+	login := now.Add(-10 * 24 * time.Hour)
+	response := now.Add(-5 * 24 * time.Hour)
+
+	responses := []models.SurveyResponse{{Sentiment: -0.5}, {Sentiment: 0.3}}
+	activity := &models.UserActivity{
+		LastLoginAt:         &login,
+		LastResponseAt:      &response,
+		InvitationsSent:     4,
+		InvitationsAnswered: 2,
+	}
+
+	features := calculateChurnFeatures(responses, activity, now.Add(-90*24*time.Hour), now)
+	if features.ResponseRate != 0.5 {
+		t.Errorf("Expected response rate 0.5, got %f", features.ResponseRate)
+	}
+	if features.DaysInactive != 5 {
+		t.Errorf("Expected 5 days inactive, got %d", features.DaysInactive)
+	}
+	if features.LastLoginDays != 10 {
+		t.Errorf("Expected 10 days since login, got %d", features.LastLoginDays)
+	}
+	if features.NegativeResponses != 1 || features.TotalResponses != 2 {
+		t.Errorf("Expected 1 of 2 negative responses, got %d of %d", features.NegativeResponses, features.TotalResponses)
+	}
+}
+
+func TestCalculateChurnFeaturesSkipsPending(t *testing.T) {
+	now := time.Now()
+	responses := []models.SurveyResponse{
+		{Sentiment: -0.5},
+		{Sentiment: 0.3},
+		{SentimentStatus: models.SentimentPending},
+	}
+
+	features := calculateChurnFeatures(responses, &models.UserActivity{}, now, now)
+	if features.NegativeResponses != 1 || features.TotalResponses != 2 {
+		t.Errorf("Expected 1 of 2 scored responses negative, got %d of %d", features.NegativeResponses, features.TotalResponses)
+	}
+}
+
+func TestCalculateChurnFeaturesNoActivity(t *testing.T) {
+	now := time.Now()
+	features := calculateChurnFeatures(nil, &models.UserActivity{}, now.Add(-20*24*time.Hour), now)
+
+	if features.DaysInactive != 20 {
+		t.Errorf("Expected inactivity since joining (20 days), got %d", features.DaysInactive)
+	}
+	if features.ResponseRate != 1.0 {
+		t.Errorf("Expected full response rate with no invitations, got %f", features.ResponseRate)
+	}
+}
+
+func TestCalculateChurnFeaturesBurnout(t *testing.T) {
+	now := time.Now()
+	high, low := 0.8, 0.2
+	responses := []models.SurveyResponse{
+		{BurnoutIndex: &high},
+		{BurnoutIndex: &low},
+		{}, // no free text
+		{BurnoutIndex: &high, SentimentStatus: models.SentimentPending}, // not scored yet
+	}
+
+	features := calculateChurnFeatures(responses, &models.UserActivity{}, now, now)
+	if features.BurnoutIndex != 0.5 {
+		t.Errorf("Expected the average over scored responses with free text (0.5), got %f", features.BurnoutIndex)
+	}
+}
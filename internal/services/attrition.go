@@ -0,0 +1,44 @@
+package services
+
+import (
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+)
+
+// AttritionWindow is the trailing period actual attrition is measured over
+const AttritionWindow = 365 * 24 * time.Hour
+
+// AttritionStats is the actual attrition over a period. Rates are percentages of
+// everyone employed during the period: current headcount plus those who left.
+type AttritionStats struct {
+	Exits          int     `json:"exits"`
+	VoluntaryExits int     `json:"voluntary_exits"`
+	RegrettedExits int     `json:"regretted_exits"`
+	Rate           float64 `json:"rate"`
+	VoluntaryRate  float64 `json:"voluntary_rate"`
+}
+
+// CalculateAttrition counts exits on or after since against the current headcount
+func CalculateAttrition(exits []models.EmployeeExit, headcount int, since time.Time) AttritionStats {
+	stats := AttritionStats{}
+	for _, exit := range exits {
+		if exit.ExitDate.Before(since) {
+			continue
+		}
+		stats.Exits++
+		if exit.ExitType == models.ExitVoluntary {
+			stats.VoluntaryExits++
+		}
+		if exit.Regretted {
+			stats.RegrettedExits++
+		}
+	}
+
+	employed := headcount + stats.Exits
+	if employed > 0 {
+		stats.Rate = float64(stats.Exits) / float64(employed) * 100
+		stats.VoluntaryRate = float64(stats.VoluntaryExits) / float64(employed) * 100
+	}
+	return stats
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+)
+
+func TestCalculateAttrition(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	since := now.Add(-AttritionWindow)
+	exits := []models.EmployeeExit{
+		{ExitDate: now.AddDate(0, -1, 0), ExitType: models.ExitVoluntary, Regretted: true},
+		{ExitDate: now.AddDate(0, -3, 0), ExitType: models.ExitInvoluntary},
+		{ExitDate: now.AddDate(-2, 0, 0), ExitType: models.ExitVoluntary}, // outside the window
+	}
+
+	stats := CalculateAttrition(exits, 18, since)
+
+	if stats.Exits != 2 {
+		t.Errorf("Expected 2 exits, got %d", stats.Exits)
+	}
+	if stats.VoluntaryExits != 1 || stats.RegrettedExits != 1 {
+		t.Errorf("Expected 1 voluntary and 1 regretted exit, got %d and %d", stats.VoluntaryExits, stats.RegrettedExits)
+	}
+	if stats.Rate != 10 {
+		t.Errorf("Expected rate 10, got %f", stats.Rate)
+	}
+	if stats.VoluntaryRate != 5 {
+		t.Errorf("Expected voluntary rate 5, got %f", stats.VoluntaryRate)
+	}
+
+	if empty := CalculateAttrition(nil, 0, since); empty.Rate != 0 {
+		t.Errorf("Expected rate 0 with no employees, got %f", empty.Rate)
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+	"github.com/VinVorteX/NoBurn/internal/repository"
+	"github.com/VinVorteX/NoBurn/internal/services/sentiment"
+)
+
+type ChurnTrainingService struct {
+	modelRepo *repository.ChurnModelRepository
+	options   sentiment.TrainOptions
+}
+
+func NewChurnTrainingService() *ChurnTrainingService {
+	return &ChurnTrainingService{
+		modelRepo: repository.NewChurnModelRepository(),
+		options:   sentiment.DefaultTrainOptions,
+	}
+}
+
+// ForTenant returns a copy of the service that only reads and writes the tenant's rows
+func (s *ChurnTrainingService) ForTenant(ctx context.Context) *ChurnTrainingService {
+	scoped := *s
+	scoped.modelRepo = s.modelRepo.ForTenant(ctx)
+	return &scoped
+}
+
+// Train fits a new model version for the company from past risk calculations and
+// who has since left. It returns sentiment.ErrInsufficientData if the company does
+// not have enough history yet, in which case the default weights stay in use.
+func (s *ChurnTrainingService) Train(companyID uint) (*models.ChurnModel, error) {
+	snapshots, err := s.modelRepo.GetTrainingData(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]sentiment.TrainingSample, len(snapshots))
+	for i, snapshot := range snapshots {
+		samples[i] = sentiment.TrainingSample{Features: fromFeatureSnapshot(snapshot.Features), Left: snapshot.Left}
+	}
+
+	trained, err := sentiment.TrainChurnModel(samples, s.options)
+	if err != nil {
+		return nil, err
+	}
+
+	model := buildChurnModel(companyID, trained, samples)
+	if err := s.modelRepo.Save(model); err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+func buildChurnModel(companyID uint, trained *sentiment.ChurnModel, samples []sentiment.TrainingSample) *models.ChurnModel {
+	leavers, correct := 0, 0
+	for _, sample := range samples {
+		if sample.Left {
+			leavers++
+		}
+		if (trained.Predict(sample.Features) >= 0.5) == sample.Left {
+			correct++
+		}
+	}
+
+	accuracy := 0.0
+	if len(samples) > 0 {
+		accuracy = float64(correct) / float64(len(samples))
+	}
+
+	return &models.ChurnModel{
+		CompanyID: companyID,
+		Features:  models.StringArray(sentiment.FeatureNames),
+		Weights:   models.Float64Array(trained.Weights),
+		Bias:      trained.Bias,
+		Samples:   len(samples),
+		Leavers:   leavers,
+		Accuracy:  accuracy,
+	}
+}
+
+func toSentimentModel(model *models.ChurnModel) *sentiment.ChurnModel {
+	return &sentiment.ChurnModel{Weights: model.Weights, Bias: model.Bias}
+}
+
+func toFeatureSnapshot(features sentiment.ChurnFeatures) models.ChurnFeatureSnapshot {
+	return models.ChurnFeatureSnapshot{
+		AvgSentiment:      features.AvgSentiment,
+		ResponseRate:      features.ResponseRate,
+		DaysInactive:      features.DaysInactive,
+		NegativeResponses: features.NegativeResponses,
+		TotalResponses:    features.TotalResponses,
+		LastLoginDays:     features.LastLoginDays,
+		BurnoutIndex:      features.BurnoutIndex,
+	}
+}
+
+func fromFeatureSnapshot(snapshot models.ChurnFeatureSnapshot) sentiment.ChurnFeatures {
+	return sentiment.ChurnFeatures{
+		AvgSentiment:      snapshot.AvgSentiment,
+		ResponseRate:      snapshot.ResponseRate,
+		DaysInactive:      snapshot.DaysInactive,
+		NegativeResponses: snapshot.NegativeResponses,
+		TotalResponses:    snapshot.TotalResponses,
+		LastLoginDays:     snapshot.LastLoginDays,
+		BurnoutIndex:      snapshot.BurnoutIndex,
+	}
+}
+
+func toContributionList(contributions []sentiment.Contribution) models.ContributionList {
+	list := make(models.ContributionList, len(contributions))
+	for i, c := range contributions {
+		list[i] = models.FeatureContribution{Feature: c.Feature, Label: c.Label, Value: c.Value, Impact: c.Impact, Share: c.Share}
+	}
+	return list
+}
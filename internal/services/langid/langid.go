@@ -0,0 +1,239 @@
+// Package langid identifies the language of short survey answers without any
+// network call. Words are first grouped by script, which settles most Indian
+// languages outright; languages sharing a script (English and romanized Hindi or
+// Tamil, Hindi and Marathi) are then told apart with character n-gram profiles
+// trained on the embedded sample texts.
+package langid
+
+import (
+	"embed"
+	"math"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+//go:embed profiles/*.txt
+var profileFiles embed.FS
+
+const maxGram = 3
+
+// tieMargin is how far, in average log-probability per n-gram, the tiebreaker
+// language may trail the best guess in a one-word text and still win. It shrinks
+// with the square root of the word count, as longer texts give clearer scores.
+const tieMargin = 0.5
+
+// scripts are the writing systems told apart before any n-gram scoring
+var scripts = []struct {
+	name  string
+	table *unicode.RangeTable
+}{
+	{"Latin", unicode.Latin},
+	{"Devanagari", unicode.Devanagari},
+	{"Bengali", unicode.Bengali},
+	{"Tamil", unicode.Tamil},
+	{"Telugu", unicode.Telugu},
+	{"Kannada", unicode.Kannada},
+	{"Malayalam", unicode.Malayalam},
+	{"Gujarati", unicode.Gujarati},
+}
+
+type profile struct {
+	language string
+	script   string
+	logProb  [maxGram + 1]map[string]float64
+	unseen   [maxGram + 1]float64
+}
+
+var profiles = mustLoadProfiles()
+
+// Languages lists the languages Detect can return besides the tiebreaker
+func Languages() []string {
+	languages := make([]string, 0, len(profiles))
+	for _, p := range profiles {
+		languages = append(languages, p.language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Detect returns the language of text. The script most of its words are written in
+// picks the candidates, and n-grams pick among them. tiebreaker, normally the
+// company language, is returned for text without words and wins close calls.
+func Detect(text, tiebreaker string) string {
+	words := map[string][]string{}
+	for _, word := range splitWords(text) {
+		if script := scriptOf(word); script != "" {
+			words[script] = append(words[script], word)
+		}
+	}
+
+	script, most := "", 0
+	for _, s := range scripts {
+		if len(words[s.name]) > most {
+			script, most = s.name, len(words[s.name])
+		}
+	}
+	if script == "" {
+		return tiebreaker
+	}
+
+	candidates := []*profile{}
+	for _, p := range profiles {
+		if p.script == script {
+			candidates = append(candidates, p)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return tiebreaker
+	case 1:
+		return candidates[0].language
+	}
+
+	grams := ngrams(words[script])
+	best, bestScore := "", math.Inf(-1)
+	tieScore, hasTie := 0.0, false
+	for _, p := range candidates {
+		score := p.score(grams)
+		if score > bestScore {
+			best, bestScore = p.language, score
+		}
+		if p.language == tiebreaker {
+			tieScore, hasTie = score, true
+		}
+	}
+	if hasTie && bestScore-tieScore <= tieMargin/math.Sqrt(float64(len(words[script]))) {
+		return tiebreaker
+	}
+	return best
+}
+
+// score is the average log-probability of grams under the profile
+func (p *profile) score(grams []string) float64 {
+	if len(grams) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, gram := range grams {
+		n := len([]rune(gram))
+		if lp, ok := p.logProb[n][gram]; ok {
+			total += lp
+		} else {
+			total += p.unseen[n]
+		}
+	}
+	return total / float64(len(grams))
+}
+
+// splitWords lowercases text and splits it into words of letters and combining
+// marks, dropping zero-width joiners
+func splitWords(text string) []string {
+	text = strings.NewReplacer("\u200C", "", "\u200D", "").Replace(strings.ToLower(text))
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsMark(r)
+	})
+}
+
+func scriptOf(word string) string {
+	for _, r := range word {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		for _, s := range scripts {
+			if unicode.Is(s.table, r) {
+				return s.name
+			}
+		}
+		return ""
+	}
+	return ""
+}
+
+// ngrams returns the 1- to maxGram-rune n-grams of each word padded with spaces,
+// so word starts and endings count
+func ngrams(words []string) []string {
+	grams := []string{}
+	for _, word := range words {
+		runes := []rune(" " + word + " ")
+		for n := 1; n <= maxGram; n++ {
+			for i := 0; i+n <= len(runes); i++ {
+				if gram := string(runes[i : i+n]); gram != " " {
+					grams = append(grams, gram)
+				}
+			}
+		}
+	}
+	return grams
+}
+
+func mustLoadProfiles() []*profile {
+	files, err := profileFiles.ReadDir("profiles")
+	if err != nil {
+		panic(err)
+	}
+
+	type counts struct {
+		language string
+		script   string
+		grams    [maxGram + 1]map[string]int
+		totals   [maxGram + 1]int
+	}
+	all := []*counts{}
+	vocabulary := [maxGram + 1]map[string]bool{}
+	for n := range vocabulary {
+		vocabulary[n] = map[string]bool{}
+	}
+
+	for _, file := range files {
+		data, err := profileFiles.ReadFile(path.Join("profiles", file.Name()))
+		if err != nil {
+			panic(err)
+		}
+		lines := []string{}
+		for _, line := range strings.Split(string(data), "\n") {
+			if !strings.HasPrefix(line, "#") {
+				lines = append(lines, line)
+			}
+		}
+		words := splitWords(strings.Join(lines, "\n"))
+
+		c := &counts{language: strings.TrimSuffix(file.Name(), path.Ext(file.Name()))}
+		scriptCounts := map[string]int{}
+		for n := range c.grams {
+			c.grams[n] = map[string]int{}
+		}
+		for _, word := range words {
+			scriptCounts[scriptOf(word)]++
+		}
+		for _, gram := range ngrams(words) {
+			n := len([]rune(gram))
+			c.grams[n][gram]++
+			c.totals[n]++
+			vocabulary[n][gram] = true
+		}
+		for script, count := range scriptCounts {
+			if script != "" && count > scriptCounts[c.script] {
+				c.script = script
+			}
+		}
+		all = append(all, c)
+	}
+
+	// Add-one smoothing over the n-grams seen in any profile
+	loaded := make([]*profile, 0, len(all))
+	for _, c := range all {
+		p := &profile{language: c.language, script: c.script}
+		for n := 1; n <= maxGram; n++ {
+			denominator := float64(c.totals[n] + len(vocabulary[n]) + 1)
+			p.logProb[n] = make(map[string]float64, len(c.grams[n]))
+			for gram, count := range c.grams[n] {
+				p.logProb[n][gram] = math.Log(float64(count+1) / denominator)
+			}
+			p.unseen[n] = math.Log(1 / denominator)
+		}
+		loaded = append(loaded, p)
+	}
+	return loaded
+}
@@ -0,0 +1,51 @@
+package langid
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		tiebreaker string
+		want       string
+	}{
+		{"English", "My manager never listens to the team", "hi", "en"},
+		{"English in a Hindi company", "The workload is too high", "hi", "en"},
+		{"Tiebreaker loses a clear call", "The workload is too high", "hi-Latn", "en"},
+		{"Hinglish", "kaam bahut zyada hai, bilkul khush nahi hu", "en", "hi-Latn"},
+		{"Hinglish with English words", "manager ka attitude bilkul bekaar hai", "en", "hi-Latn"},
+		{"Short Hinglish", "mujhe chutti chahiye", "en", "hi-Latn"},
+		{"Tanglish", "vela romba kashtam ah irukku", "en", "ta-Latn"},
+		{"Short Tanglish", "enakku pidikkala", "en", "ta-Latn"},
+		{"Hindi", "काम बहुत ज़्यादा है और कोई सुनता नहीं", "en", "hi"},
+		{"Marathi", "काम खूप जास्त आहे आणि कोणी ऐकत नाही", "en", "mr"},
+		{"Code-mixed Devanagari", "मेरा manager बहुत rude है", "en", "hi"},
+		{"Kannada in an English company", "ತುಂಬಾ ಒತ್ತಡ ಇದೆ", "en", "kn"},
+		{"Tamil", "வேலை நன்றாக இருக்கிறது", "en", "ta"},
+		{"Bengali", "আমি খুব ক্লান্ত", "en", "bn"},
+		{"Telugu", "పని చాలా బాగుంది", "en", "te"},
+		{"Malayalam", "ടീം വളരെ നല്ലത്", "en", "ml"},
+		{"Gujarati", "પગાર સારો નથી", "en", "gu"},
+		{"No words", "👍 5", "gu", "gu"},
+		{"Ambiguous word goes to the tiebreaker", "ok", "hi-Latn", "hi-Latn"},
+		{"Marathi tiebreaker", "छान", "mr", "mr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.text, tt.tiebreaker); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	want := []string{"bn", "en", "gu", "hi", "hi-Latn", "kn", "ml", "mr", "ta", "ta-Latn", "te"}
+	if got := Languages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
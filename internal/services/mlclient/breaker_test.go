@@ -0,0 +1,74 @@
+package mlclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+	breaker := NewBreaker(3, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		breaker.Failure()
+	}
+	if !breaker.Allow() {
+		t.Fatal("Expected calls below the threshold to be allowed")
+	}
+
+	breaker.Failure()
+	if breaker.Allow() || !breaker.Open() {
+		t.Fatal("Expected the breaker to open at the threshold")
+	}
+
+	now = now.Add(time.Minute)
+	if !breaker.Allow() {
+		t.Fatal("Expected a trial call after the cooldown")
+	}
+	if breaker.Allow() {
+		t.Error("Expected only one trial call at a time")
+	}
+
+	breaker.Failure()
+	if breaker.Allow() {
+		t.Error("Expected a failed trial to reopen the breaker")
+	}
+
+	now = now.Add(time.Minute)
+	breaker.Allow()
+	breaker.Success()
+	if !breaker.Allow() || breaker.Open() {
+		t.Error("Expected a successful trial to close the breaker")
+	}
+}
+
+func TestBreakerRelease(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+	breaker := NewBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.Failure()
+	now = now.Add(time.Minute)
+	if !breaker.Allow() {
+		t.Fatal("Expected a trial call after the cooldown")
+	}
+
+	breaker.Release()
+	if !breaker.Allow() {
+		t.Error("Expected a released trial to let the next call try")
+	}
+	if breaker.Open() {
+		t.Error("Expected a release not to restart the cooldown")
+	}
+}
+
+func TestBreakerDisabled(t *testing.T) {
+	breaker := NewBreaker(0, time.Minute)
+	for i := 0; i < 10; i++ {
+		breaker.Failure()
+	}
+	if !breaker.Allow() {
+		t.Error("Expected a zero threshold to never open")
+	}
+}
@@ -0,0 +1,185 @@
+// Package mlclient is the HTTP client used for model inference calls. It retries
+// transient failures, stops calling a failing endpoint for a while, and limits how
+// many calls each company can make so one tenant can't exhaust the quota.
+package mlclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrCircuitOpen = errors.New("circuit breaker open")
+	ErrRateLimited = errors.New("company rate limit reached")
+)
+
+type Config struct {
+	Timeout          time.Duration // per attempt
+	MaxRetries       int           // extra attempts after a network error, 429 or 5xx
+	RetryBackoff     time.Duration // doubled after each retry
+	MaxRetryWait     time.Duration // give up instead of waiting longer than this for one retry
+	BreakerThreshold int           // consecutive failed calls that open the breaker
+	BreakerCooldown  time.Duration // how long the breaker stays open
+	CompanyRate      float64       // calls per minute per company, 0 for no limit
+	CompanyBurst     int
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Timeout:          10 * time.Second,
+		MaxRetries:       2,
+		RetryBackoff:     500 * time.Millisecond,
+		MaxRetryWait:     5 * time.Second,
+		BreakerThreshold: 5,
+		BreakerCooldown:  30 * time.Second,
+		CompanyRate:      60,
+		CompanyBurst:     10,
+	}
+}
+
+type Client struct {
+	config  Config
+	http    *http.Client
+	breaker *Breaker
+	limiter *Limiter
+}
+
+func New(config Config) *Client {
+	return &Client{
+		config:  config,
+		http:    &http.Client{Timeout: config.Timeout},
+		breaker: NewBreaker(config.BreakerThreshold, config.BreakerCooldown),
+		limiter: NewLimiter(config.CompanyRate, config.CompanyBurst),
+	}
+}
+
+// Post sends payload as JSON and returns the body of the first 200 response. Calls
+// fail fast with ErrCircuitOpen or ErrRateLimited so callers can use their fallback.
+func (c *Client) Post(ctx context.Context, url, token string, payload interface{}) ([]byte, error) {
+	if companyID, ok := CompanyFrom(ctx); ok && !c.limiter.Allow(companyID) {
+		return nil, ErrRateLimited
+	}
+	if !c.breaker.Allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	body, err := c.postWithRetry(ctx, url, token, payload)
+	var rejected *rejectedError
+	switch {
+	case err == nil:
+		c.breaker.Success()
+		return body, nil
+	case ctx.Err() != nil || errors.As(err, &rejected):
+		// A cancelled call or a refused request says nothing about the endpoint being down
+		c.breaker.Release()
+	default:
+		c.breaker.Failure()
+	}
+	return nil, err
+}
+
+// rejectedError is a failure caused by the request rather than the endpoint: the
+// payload couldn't be encoded or the server answered with a 4xx other than 429
+type rejectedError struct {
+	err error
+}
+
+func (e *rejectedError) Error() string { return e.err.Error() }
+
+func (e *rejectedError) Unwrap() error { return e.err }
+
+func (c *Client) postWithRetry(ctx context.Context, url, token string, payload interface{}) ([]byte, error) {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, &rejectedError{err}
+	}
+
+	backoff := c.config.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		body, status, header, err := c.do(ctx, url, token, jsonData)
+		if err == nil && status == http.StatusOK {
+			return body, nil
+		}
+		if err == nil {
+			err = fmt.Errorf("status %d: %s", status, string(body))
+			if status != http.StatusTooManyRequests && status < 500 {
+				return nil, &rejectedError{err}
+			}
+		}
+		if attempt >= c.config.MaxRetries {
+			return nil, err
+		}
+
+		wait := backoff
+		if suggested := retryAfter(body, header); suggested > wait {
+			wait = suggested
+		}
+		if c.config.MaxRetryWait > 0 && wait > c.config.MaxRetryWait {
+			return nil, fmt.Errorf("%w (retry in %s exceeds limit)", err, wait)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) do(ctx context.Context, url, token string, jsonData []byte) ([]byte, int, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	return body, resp.StatusCode, resp.Header, nil
+}
+
+// retryAfter reads how long the server asked us to wait: Hugging Face reports
+// estimated_time (seconds) while a model is loading, others send Retry-After
+func retryAfter(body []byte, header http.Header) time.Duration {
+	var loading struct {
+		EstimatedTime float64 `json:"estimated_time"`
+	}
+	if json.Unmarshal(body, &loading) == nil && loading.EstimatedTime > 0 {
+		return time.Duration(loading.EstimatedTime * float64(time.Second))
+	}
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return 0
+}
+
+type companyKey struct{}
+
+// WithCompany attributes calls made with ctx to a company's rate limit
+func WithCompany(ctx context.Context, companyID uint) context.Context {
+	return context.WithValue(ctx, companyKey{}, companyID)
+}
+
+// CompanyFrom returns the company set by WithCompany. Calls without one aren't limited.
+func CompanyFrom(ctx context.Context) (uint, bool) {
+	companyID, ok := ctx.Value(companyKey{}).(uint)
+	return companyID, ok
+}
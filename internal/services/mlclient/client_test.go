@@ -0,0 +1,168 @@
+package mlclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPostRetriesModelLoading(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error": "Model is currently loading", "estimated_time": 0.05}`))
+			return
+		}
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	client := New(Config{Timeout: time.Second, MaxRetries: 2, RetryBackoff: time.Millisecond, MaxRetryWait: time.Second})
+	start := time.Now()
+	body, err := client.Post(context.Background(), server.URL, "", map[string]string{})
+	if err != nil {
+		t.Fatalf("Expected the retry to succeed, got %v", err)
+	}
+	if string(body) != `{"ok": true}` {
+		t.Errorf("Expected the second response body, got %s", body)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected to wait the estimated 50ms, waited %s", elapsed)
+	}
+}
+
+func TestPostGivesUpOnLongEstimatedTime(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error": "Model is currently loading", "estimated_time": 20}`))
+	}))
+	defer server.Close()
+
+	client := New(Config{Timeout: time.Second, MaxRetries: 3, RetryBackoff: time.Millisecond, MaxRetryWait: time.Second})
+	if _, err := client.Post(context.Background(), server.URL, "", map[string]string{}); err == nil {
+		t.Fatal("Expected error when the model needs longer than the retry limit")
+	}
+	if attempts != 1 {
+		t.Errorf("Expected a single attempt, got %d", attempts)
+	}
+}
+
+func TestPostDoesNotRetryClientErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := New(Config{Timeout: time.Second, MaxRetries: 3, RetryBackoff: time.Millisecond})
+	if _, err := client.Post(context.Background(), server.URL, "", map[string]string{}); err == nil {
+		t.Error("Expected error for 401")
+	}
+	if attempts != 1 {
+		t.Errorf("Expected a single attempt for a client error, got %d", attempts)
+	}
+}
+
+func TestPostShortCircuits(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := New(Config{Timeout: time.Second, BreakerThreshold: 2, BreakerCooldown: time.Minute})
+	for i := 0; i < 2; i++ {
+		client.Post(context.Background(), server.URL, "", map[string]string{})
+	}
+
+	_, err := client.Post(context.Background(), server.URL, "", map[string]string{})
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected no request while the breaker is open, got %d", attempts)
+	}
+}
+
+func TestPostCancelledTrialReleasesBreaker(t *testing.T) {
+	fail := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := New(Config{Timeout: time.Second, BreakerThreshold: 1, BreakerCooldown: time.Millisecond})
+	client.Post(context.Background(), server.URL, "", map[string]string{})
+	time.Sleep(2 * time.Millisecond)
+
+	// The trial call is cancelled before it is sent
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Post(ctx, server.URL, "", map[string]string{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the cancelled trial to fail with context.Canceled, got %v", err)
+	}
+
+	fail = false
+	if _, err := client.Post(context.Background(), server.URL, "", map[string]string{}); err != nil {
+		t.Errorf("Expected the next call to be let through as a trial, got %v", err)
+	}
+}
+
+func TestPostClientErrorsDoNotOpenBreaker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := New(Config{Timeout: time.Second, BreakerThreshold: 2, BreakerCooldown: time.Minute})
+	for i := 0; i < 3; i++ {
+		if _, err := client.Post(context.Background(), server.URL, "", map[string]string{}); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("Expected 400s not to open the breaker, call %d got %v", i+1, err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.Post(context.Background(), server.URL, "", func() {}); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("Expected an encoding error, got %v", err)
+		}
+	}
+	if client.breaker.Open() {
+		t.Error("Expected encoding errors not to open the breaker")
+	}
+}
+
+func TestPostRateLimitsPerCompany(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := New(Config{Timeout: time.Second, CompanyRate: 1, CompanyBurst: 1})
+	companyA := WithCompany(context.Background(), 1)
+	companyB := WithCompany(context.Background(), 2)
+
+	if _, err := client.Post(companyA, server.URL, "", map[string]string{}); err != nil {
+		t.Fatalf("Expected the first call to pass, got %v", err)
+	}
+	if _, err := client.Post(companyA, server.URL, "", map[string]string{}); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited for the second call, got %v", err)
+	}
+	if _, err := client.Post(companyB, server.URL, "", map[string]string{}); err != nil {
+		t.Errorf("Expected another company to have its own bucket, got %v", err)
+	}
+	if _, err := client.Post(context.Background(), server.URL, "", map[string]string{}); err != nil {
+		t.Errorf("Expected calls without a company not to be limited, got %v", err)
+	}
+}
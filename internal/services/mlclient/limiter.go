@@ -0,0 +1,63 @@
+package mlclient
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter keeps one token bucket per company, refilled at perMinute and holding at
+// most burst tokens
+type Limiter struct {
+	mu        sync.Mutex
+	perMinute float64
+	burst     float64
+	buckets   map[uint]*bucket
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter returns a limiter that allows everything when perMinute is 0
+func NewLimiter(perMinute float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		perMinute: perMinute,
+		burst:     float64(burst),
+		buckets:   map[uint]*bucket{},
+		now:       time.Now,
+	}
+}
+
+// Allow takes a token from the company's bucket if one is available
+func (l *Limiter) Allow(companyID uint) bool {
+	if l.perMinute <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[companyID]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[companyID] = b
+	}
+
+	b.tokens += now.Sub(b.last).Minutes() * l.perMinute
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,35 @@
+package mlclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiterRefills(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(60, 2)
+	limiter.now = func() time.Time { return now }
+
+	if !limiter.Allow(1) || !limiter.Allow(1) {
+		t.Fatal("Expected the burst to be available")
+	}
+	if limiter.Allow(1) {
+		t.Fatal("Expected the bucket to be empty")
+	}
+
+	now = now.Add(time.Second)
+	if !limiter.Allow(1) {
+		t.Error("Expected one token after a second at 60 per minute")
+	}
+
+	now = now.Add(time.Hour)
+	allowed := 0
+	for i := 0; i < 5; i++ {
+		if limiter.Allow(1) {
+			allowed++
+		}
+	}
+	if allowed != 2 {
+		t.Errorf("Expected the refill to be capped at the burst of 2, got %d", allowed)
+	}
+}
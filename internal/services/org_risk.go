@@ -0,0 +1,94 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+)
+
+// GroupRisk is the attrition picture for one department or team. ID is nil for
+// employees that are not assigned to one.
+type GroupRisk struct {
+	ID           *uint   `json:"id"`
+	Name         string  `json:"name"`
+	Employees    int     `json:"employees"`
+	AtRisk       int     `json:"at_risk"`
+	AtRiskRate   float64 `json:"at_risk_rate"` // percent of employees
+	MaxRiskScore float64 `json:"max_risk_score"`
+}
+
+// RiskByDepartment groups employees by department and counts each at-risk employee once
+func RiskByDepartment(employees []models.User, risks []models.AttritionRisk) []GroupRisk {
+	return groupRisk(employees, risks, func(u models.User) (*uint, string) {
+		if u.DepartmentID == nil {
+			return nil, ""
+		}
+		if u.Department != nil {
+			return u.DepartmentID, u.Department.Name
+		}
+		return u.DepartmentID, ""
+	})
+}
+
+// RiskByTeam groups employees by team and counts each at-risk employee once
+func RiskByTeam(employees []models.User, risks []models.AttritionRisk) []GroupRisk {
+	return groupRisk(employees, risks, func(u models.User) (*uint, string) {
+		if u.TeamID == nil {
+			return nil, ""
+		}
+		if u.Team != nil {
+			return u.TeamID, u.Team.Name
+		}
+		return u.TeamID, ""
+	})
+}
+
+func groupRisk(employees []models.User, risks []models.AttritionRisk, key func(models.User) (*uint, string)) []GroupRisk {
+	maxScore := map[uint]float64{}
+	for _, risk := range risks {
+		if score, ok := maxScore[risk.UserID]; !ok || risk.RiskScore > score {
+			maxScore[risk.UserID] = risk.RiskScore
+		}
+	}
+
+	groups := map[uint]*GroupRisk{}
+	unassigned := &GroupRisk{Name: "Unassigned"}
+	for _, emp := range employees {
+		id, name := key(emp)
+		group := unassigned
+		if id != nil {
+			if groups[*id] == nil {
+				groups[*id] = &GroupRisk{ID: id, Name: name}
+			}
+			group = groups[*id]
+		}
+
+		group.Employees++
+		if score, ok := maxScore[emp.ID]; ok {
+			group.AtRisk++
+			if score > group.MaxRiskScore {
+				group.MaxRiskScore = score
+			}
+		}
+	}
+
+	result := []GroupRisk{}
+	for _, group := range groups {
+		result = append(result, *group)
+	}
+	if unassigned.Employees > 0 {
+		result = append(result, *unassigned)
+	}
+	for i := range result {
+		result[i].AtRiskRate = float64(result[i].AtRisk) / float64(result[i].Employees) * 100
+	}
+
+	// Most at-risk groups first
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].AtRiskRate != result[j].AtRiskRate {
+			return result[i].AtRiskRate > result[j].AtRiskRate
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
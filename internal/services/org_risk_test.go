@@ -0,0 +1,48 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+)
+
+func TestRiskByDepartment(t *testing.T) {
+	eng := uint(1)
+	sales := uint(2)
+	employees := []models.User{
+		{ID: 1, DepartmentID: &eng, Department: &models.Department{ID: eng, Name: "Engineering"}},
+		{ID: 2, DepartmentID: &eng, Department: &models.Department{ID: eng, Name: "Engineering"}},
+		{ID: 3, DepartmentID: &sales, Department: &models.Department{ID: sales, Name: "Sales"}},
+		{ID: 4},
+	}
+	// User 1 has two risk records and must only be counted once
+	risks := []models.AttritionRisk{
+		{UserID: 1, RiskScore: 0.8},
+		{UserID: 1, RiskScore: 0.9},
+		{UserID: 3, RiskScore: 0.75},
+	}
+
+	groups := RiskByDepartment(employees, risks)
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %d", len(groups))
+	}
+
+	if groups[0].Name != "Sales" || groups[0].AtRisk != 1 || groups[0].AtRiskRate != 100 {
+		t.Errorf("Expected Sales first at 100%%, got %+v", groups[0])
+	}
+	if groups[1].Name != "Engineering" || groups[1].Employees != 2 || groups[1].AtRisk != 1 {
+		t.Errorf("Expected Engineering with 1 of 2 at risk, got %+v", groups[1])
+	}
+	if groups[1].MaxRiskScore != 0.9 {
+		t.Errorf("Expected Engineering max risk 0.9, got %f", groups[1].MaxRiskScore)
+	}
+	if groups[2].ID != nil || groups[2].Name != "Unassigned" || groups[2].AtRisk != 0 {
+		t.Errorf("Expected unassigned group with no risk, got %+v", groups[2])
+	}
+}
+
+func TestRiskByTeamNoEmployees(t *testing.T) {
+	if groups := RiskByTeam(nil, nil); len(groups) != 0 {
+		t.Errorf("Expected no groups, got %d", len(groups))
+	}
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+)
+
+const oneWeek = 7 * 24 * time.Hour
+
+// RiskWeek is an employee's risk as of the end of one week
+type RiskWeek struct {
+	WeekStart  time.Time `json:"week_start"` // Monday 00:00 UTC
+	RiskScore  float64   `json:"risk_score"`
+	Delta      *float64  `json:"delta"`      // change from the previous week, nil for the first week
+	Calculated bool      `json:"calculated"` // false when carried over from an earlier week
+}
+
+// WeeklyRiskTrend turns risk history into one point per week from the first calculation
+// up to the week containing now. Weeks without a calculation carry the previous score
+// over, so deltas are always against the week before. risks must be oldest first.
+func WeeklyRiskTrend(risks []models.AttritionRisk, now time.Time) []RiskWeek {
+	weeks := []RiskWeek{}
+	if len(risks) == 0 {
+		return weeks
+	}
+
+	next := 0
+	var previous *RiskWeek
+	for start := weekStart(risks[0].CreatedAt); !start.After(now); start = start.Add(oneWeek) {
+		current := RiskWeek{WeekStart: start}
+		if previous != nil {
+			current.RiskScore = previous.RiskScore
+		}
+
+		// The last calculation in the week is the week's score
+		for next < len(risks) && risks[next].CreatedAt.Before(start.Add(oneWeek)) {
+			current.RiskScore = risks[next].RiskScore
+			current.Calculated = true
+			next++
+		}
+
+		if previous != nil {
+			delta := current.RiskScore - previous.RiskScore
+			current.Delta = &delta
+		}
+		weeks = append(weeks, current)
+		previous = &weeks[len(weeks)-1]
+	}
+	return weeks
+}
+
+// weekStart returns the Monday 00:00 UTC starting t's week
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+)
+
+func TestWeeklyRiskTrend(t *testing.T) {
+	// Monday 2026-06-01
+	monday := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	risks := []models.AttritionRisk{
+		{RiskScore: 0.3, CreatedAt: monday.Add(24 * time.Hour)},
+		{RiskScore: 0.4, CreatedAt: monday.Add(3 * 24 * time.Hour)}, // later in the same week wins
+		{RiskScore: 0.7, CreatedAt: monday.AddDate(0, 0, 16)},       // week three; week two has none
+	}
+	now := monday.AddDate(0, 0, 22) // Tuesday of week four
+
+	weeks := WeeklyRiskTrend(risks, now)
+
+	if len(weeks) != 4 {
+		t.Fatalf("Expected 4 weeks, got %d", len(weeks))
+	}
+
+	expected := []struct {
+		score      float64
+		delta      float64
+		calculated bool
+	}{
+		{0.4, 0, true},
+		{0.4, 0, false},
+		{0.7, 0.3, true},
+		{0.7, 0, false},
+	}
+
+	for i, want := range expected {
+		got := weeks[i]
+		if !got.WeekStart.Equal(monday.AddDate(0, 0, 7*i)) {
+			t.Errorf("Week %d: expected start %v, got %v", i, monday.AddDate(0, 0, 7*i), got.WeekStart)
+		}
+		if got.RiskScore != want.score || got.Calculated != want.calculated {
+			t.Errorf("Week %d: expected score %f (calculated %v), got %f (%v)", i, want.score, want.calculated, got.RiskScore, got.Calculated)
+		}
+		if i == 0 {
+			if got.Delta != nil {
+				t.Errorf("Expected no delta for the first week, got %f", *got.Delta)
+			}
+			continue
+		}
+		if got.Delta == nil || math.Abs(*got.Delta-want.delta) > 1e-9 {
+			t.Errorf("Week %d: expected delta %f, got %v", i, want.delta, got.Delta)
+		}
+	}
+
+	if empty := WeeklyRiskTrend(nil, now); len(empty) != 0 {
+		t.Errorf("Expected no weeks without history, got %d", len(empty))
+	}
+}
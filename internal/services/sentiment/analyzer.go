@@ -0,0 +1,190 @@
+package sentiment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// Analyzer scores text from -1 (negative) to 1 (positive)
+type Analyzer interface {
+	Name() string
+	Analyze(ctx context.Context, text, language string) (Result, error)
+}
+
+// Result is an analyzer's verdict on one text
+type Result struct {
+	Score      float64        // -1 to 1
+	Confidence float64        // 0 to 1
+	Provider   string         // set by the fallback chain to the analyzer that answered
+	Language   string         // set by MLService to the language the text was scored in
+	Burnout    *BurnoutScores // from providers that classify burnout, else ClassifyBurnout via MLService
+}
+
+const (
+	ProviderHuggingFace = "huggingface"
+	ProviderModelServer = "model_server"
+	ProviderLexicon     = "lexicon"
+)
+
+// DefaultChainKey selects the chain used for languages without their own
+const DefaultChainKey = "default"
+
+// LexiconAnalyzer scores with the embedded per-language lexicons. It never fails, so
+// it belongs at the end of every chain.
+type LexiconAnalyzer struct{}
+
+func (LexiconAnalyzer) Name() string {
+	return ProviderLexicon
+}
+
+func (LexiconAnalyzer) Analyze(ctx context.Context, text, language string) (Result, error) {
+	return LexiconFor(language).Score(text), nil
+}
+
+// FallbackAnalyzer tries each analyzer in order and returns the first score
+type FallbackAnalyzer struct {
+	analyzers []Analyzer
+}
+
+func (f *FallbackAnalyzer) Name() string {
+	names := make([]string, len(f.analyzers))
+	for i, a := range f.analyzers {
+		names[i] = a.Name()
+	}
+	return strings.Join(names, ",")
+}
+
+func (f *FallbackAnalyzer) Analyze(ctx context.Context, text, language string) (Result, error) {
+	errs := []error{}
+	for _, analyzer := range f.analyzers {
+		result, err := analyzer.Analyze(ctx, text, language)
+		if err == nil {
+			result.Provider = analyzer.Name()
+			return result, nil
+		}
+		log.Printf("⚠️ Sentiment provider %s failed: %v", analyzer.Name(), err)
+		errs = append(errs, fmt.Errorf("%s: %w", analyzer.Name(), err))
+	}
+	return Result{}, fmt.Errorf("all sentiment providers failed: %w", errors.Join(errs...))
+}
+
+// Chains maps a language code, or DefaultChainKey, to provider names in fallback order
+type Chains map[string][]string
+
+// ParseChains reads a spec like "huggingface,lexicon;hi=model_server,lexicon". An
+// entry without a language sets the default chain.
+func ParseChains(spec string) (Chains, error) {
+	chains := Chains{}
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		language, providers := DefaultChainKey, entry
+		if i := strings.Index(entry, "="); i >= 0 {
+			language, providers = strings.TrimSpace(entry[:i]), entry[i+1:]
+		}
+
+		names := []string{}
+		for _, name := range strings.Split(providers, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		if language == "" || len(names) == 0 {
+			return nil, fmt.Errorf("invalid sentiment provider chain %q", entry)
+		}
+		chains[language] = names
+	}
+	return chains, nil
+}
+
+// Registry holds the available analyzers and the configured chains
+type Registry struct {
+	analyzers map[string]Analyzer
+	chains    Chains
+}
+
+// NewRegistry starts with the lexicon registered and used as the default chain
+func NewRegistry() *Registry {
+	return &Registry{
+		analyzers: map[string]Analyzer{ProviderLexicon: LexiconAnalyzer{}},
+		chains:    Chains{DefaultChainKey: {ProviderLexicon}},
+	}
+}
+
+func (r *Registry) Register(analyzer Analyzer) {
+	r.analyzers[analyzer.Name()] = analyzer
+}
+
+// SetChains replaces the configured chains after checking every provider is registered
+func (r *Registry) SetChains(chains Chains) error {
+	if err := r.Validate(chains); err != nil {
+		return err
+	}
+	for language, names := range chains {
+		r.chains[language] = names
+	}
+	return nil
+}
+
+// Validate reports chains that name providers that are not registered
+func (r *Registry) Validate(chains Chains) error {
+	for language, names := range chains {
+		for _, name := range names {
+			if _, ok := r.analyzers[name]; !ok {
+				return fmt.Errorf("unknown sentiment provider %q for %s; available: %s", name, language, strings.Join(r.Providers(), ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// Providers lists the registered provider names
+func (r *Registry) Providers() []string {
+	names := make([]string, 0, len(r.analyzers))
+	for name := range r.analyzers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Analyzer returns the chain for language. A company's own chains, language-specific
+// or default, take precedence over the configured ones. Romanized languages only use
+// chains set for them, since the default models expect native script, and otherwise
+// get the lexicon alone. The lexicon is appended when the chain doesn't already end
+// with it, so scoring always succeeds.
+func (r *Registry) Analyzer(override Chains, language string) Analyzer {
+	names := r.chainFor(override, language)
+
+	analyzers := []Analyzer{}
+	for _, name := range names {
+		if analyzer, ok := r.analyzers[name]; ok {
+			analyzers = append(analyzers, analyzer)
+		}
+	}
+	if len(analyzers) == 0 || analyzers[len(analyzers)-1].Name() != ProviderLexicon {
+		analyzers = append(analyzers, LexiconAnalyzer{})
+	}
+	return &FallbackAnalyzer{analyzers: analyzers}
+}
+
+func (r *Registry) chainFor(override Chains, language string) []string {
+	for _, chains := range []Chains{override, r.chains} {
+		if names := chains[language]; len(names) > 0 {
+			return names
+		}
+		if IsRomanized(language) {
+			continue
+		}
+		if names := chains[DefaultChainKey]; len(names) > 0 {
+			return names
+		}
+	}
+	return nil
+}
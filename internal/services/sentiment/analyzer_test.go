@@ -0,0 +1,204 @@
+package sentiment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/services/mlclient"
+)
+
+type fakeAnalyzer struct {
+	name  string
+	score float64
+	err   error
+	calls int
+}
+
+func (f *fakeAnalyzer) Name() string {
+	return f.name
+}
+
+func (f *fakeAnalyzer) Analyze(ctx context.Context, text, language string) (Result, error) {
+	f.calls++
+	return Result{Score: f.score, Confidence: 0.8}, f.err
+}
+
+func TestParseChains(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    Chains
+		wantErr bool
+	}{
+		{"Empty", "", Chains{}, false},
+		{"Default only", "huggingface, lexicon", Chains{DefaultChainKey: {"huggingface", "lexicon"}}, false},
+		{
+			name: "Per language",
+			spec: "huggingface,lexicon; hi=model_server,lexicon",
+			want: Chains{DefaultChainKey: {"huggingface", "lexicon"}, "hi": {"model_server", "lexicon"}},
+		},
+		{"Missing providers", "hi=", nil, true},
+		{"Missing language", "=lexicon", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseChains(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRegistryChainPrecedence(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(&fakeAnalyzer{name: "a"})
+	registry.Register(&fakeAnalyzer{name: "b"})
+	registry.Register(&fakeAnalyzer{name: "c"})
+	if err := registry.SetChains(Chains{DefaultChainKey: {"a"}, "hi": {"b"}}); err != nil {
+		t.Fatalf("Expected valid chains, got %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		override Chains
+		language string
+		want     string
+	}{
+		{"Global default", nil, "en", "a,lexicon"},
+		{"Global language", nil, "hi", "b,lexicon"},
+		{"Company default beats global language", Chains{DefaultChainKey: {"c"}}, "hi", "c,lexicon"},
+		{"Company language", Chains{"ta": {"c", "lexicon"}}, "ta", "c,lexicon"},
+		{"Company chain for another language", Chains{"ta": {"c"}}, "en", "a,lexicon"},
+		{"Romanized skips default chains", Chains{DefaultChainKey: {"c"}}, "hi-Latn", "lexicon"},
+		{"Romanized with its own chain", Chains{"hi-Latn": {"c"}}, "hi-Latn", "c,lexicon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := registry.Analyzer(tt.override, tt.language).Name(); got != tt.want {
+				t.Errorf("Expected chain %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRegistryRejectsUnknownProvider(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.SetChains(Chains{DefaultChainKey: {"huggingface"}}); err == nil {
+		t.Error("Expected error for unregistered provider")
+	}
+	if got := registry.Analyzer(nil, "en").Name(); got != ProviderLexicon {
+		t.Errorf("Expected rejected chains to leave the lexicon default, got %s", got)
+	}
+}
+
+func TestFallbackAnalyzer(t *testing.T) {
+	failing := &fakeAnalyzer{name: "failing", err: errors.New("unavailable")}
+	working := &fakeAnalyzer{name: "working", score: 0.6}
+	unused := &fakeAnalyzer{name: "unused", score: -0.6}
+
+	analyzer := &FallbackAnalyzer{analyzers: []Analyzer{failing, working, unused}}
+	result, err := analyzer.Analyze(context.Background(), "text", "en")
+	if err != nil {
+		t.Fatalf("Expected fallback to succeed, got %v", err)
+	}
+	if result.Score != 0.6 || result.Provider != "working" {
+		t.Errorf("Expected score from the second provider, got %+v", result)
+	}
+	if unused.calls != 0 {
+		t.Errorf("Expected providers after a success not to be called, got %d calls", unused.calls)
+	}
+
+	analyzer = &FallbackAnalyzer{analyzers: []Analyzer{failing}}
+	if _, err := analyzer.Analyze(context.Background(), "text", "en"); err == nil {
+		t.Error("Expected error when every provider fails")
+	}
+}
+
+func TestModelServerAnalyzer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req MLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Language != "hi" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(MLResponse{Sentiment: -0.4, Confidence: 0.9})
+	}))
+	defer server.Close()
+
+	analyzer := NewModelServerAnalyzer(MLConfig{ModelServerURL: server.URL}, mlclient.New(mlclient.Config{Timeout: time.Second}))
+	result, err := analyzer.Analyze(context.Background(), "थका हुआ", "hi")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Score != -0.4 || result.Confidence != 0.9 {
+		t.Errorf("Expected -0.4 with confidence 0.9, got %+v", result)
+	}
+
+	if _, err := analyzer.Analyze(context.Background(), "text", "en"); err == nil {
+		t.Error("Expected error for non-200 response")
+	}
+}
+
+func TestModelServerBurnout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req MLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		burnout := &BurnoutScores{Exhaustion: 0.9, Stress: 0.6}
+		if req.Text == "out of range" {
+			burnout.Cynicism = 1.5
+		}
+		json.NewEncoder(w).Encode(MLResponse{Sentiment: -0.7, Confidence: 0.9, Burnout: burnout})
+	}))
+	defer server.Close()
+
+	analyzer := NewModelServerAnalyzer(MLConfig{ModelServerURL: server.URL}, mlclient.New(mlclient.Config{Timeout: time.Second}))
+	result, err := analyzer.Analyze(context.Background(), "running on fumes", "en")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Burnout == nil || result.Burnout.Exhaustion != 0.9 || result.Burnout.Stress != 0.6 {
+		t.Errorf("Expected the model's burnout scores, got %+v", result.Burnout)
+	}
+
+	if _, err := analyzer.Analyze(context.Background(), "out of range", "en"); err == nil {
+		t.Error("Expected error for a burnout score above 1")
+	}
+}
+
+func TestScoreFromLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		preds   []map[string]interface{}
+		want    Result
+		wantErr bool
+	}{
+		{"Positive", []map[string]interface{}{{"label": "positive", "score": 0.8}, {"label": "negative", "score": 0.1}}, Result{Score: 0.8, Confidence: 0.8}, false},
+		{"Negative", []map[string]interface{}{{"label": "LABEL_0", "score": 0.7}}, Result{Score: -0.7, Confidence: 0.7}, false},
+		{"Neutral", []map[string]interface{}{{"label": "neutral", "score": 0.9}}, Result{Confidence: 0.9}, false},
+		{"Unknown", []map[string]interface{}{{"label": "LABEL_5", "score": 0.9}}, Result{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scoreFromLabels(tt.preds)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
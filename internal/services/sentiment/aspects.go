@@ -0,0 +1,250 @@
+package sentiment
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Workplace aspects answers are tagged with. Companies can add their own.
+const (
+	AspectCompensation    = "compensation"
+	AspectManager         = "manager"
+	AspectWorkload        = "workload"
+	AspectGrowth          = "growth"
+	AspectWorkLifeBalance = "work_life_balance"
+	AspectCulture         = "culture"
+	AspectRecognition     = "recognition"
+)
+
+// Limits on aspect definitions. Aspect names are stored in a VARCHAR(50) column.
+const (
+	maxAspectNameLength = 50
+	maxAspectKeywords   = 50 // per aspect
+	maxKeywordLength    = 100
+	maxCompanyAspects   = 30
+)
+
+var aspectNames = map[string]string{
+	AspectCompensation:    "Compensation",
+	AspectManager:         "Manager",
+	AspectWorkload:        "Workload",
+	AspectGrowth:          "Career growth",
+	AspectWorkLifeBalance: "Work-life balance",
+	AspectCulture:         "Culture",
+	AspectRecognition:     "Recognition",
+}
+
+// AspectName is the display name of an aspect; company aspects are title-cased
+func AspectName(aspect string) string {
+	if name, ok := aspectNames[aspect]; ok {
+		return name
+	}
+	name := strings.ReplaceAll(aspect, "_", " ")
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// Aspect taxonomy files have one aspect per line, # starts a comment:
+//
+//	compensation: salary, pay, bonus*, salary hike
+//
+// Terms may be phrases, and a trailing * matches any word starting with the stem.
+// English terms apply to every language, since answers mix English words in.
+//
+//go:embed aspects/*.txt
+var aspectFiles embed.FS
+
+type aspectTerm struct {
+	aspect string
+	words  []string
+	stem   bool // the last word is a prefix
+}
+
+var taxonomies = mustLoadTaxonomies(aspectFiles, "aspects")
+
+// AspectMention is an aspect found in one answer, with the sentiment of the clauses
+// that mention it
+type AspectMention struct {
+	Aspect    string
+	Sentiment float64
+	Mentions  int
+}
+
+// ExtractAspects finds the aspects text talks about, using the taxonomy for
+// language plus the company's own keywords per aspect. Each mentioning clause is
+// scored with the lexicon, so "great team but the salary is too low" is positive on
+// culture and negative on compensation; clauses without any sentiment words take
+// fallback, the score of the whole answer.
+func ExtractAspects(text, language string, companyKeywords map[string][]string, fallback float64) []AspectMention {
+	terms := taxonomyFor(taxonomies, language)
+	if len(companyKeywords) > 0 {
+		extra, err := parseAspectTerms(companyKeywords)
+		if err == nil {
+			terms = append(append([]aspectTerm{}, terms...), extra...)
+		}
+	}
+
+	lexicon := LexiconFor(language)
+	totals := map[string]float64{}
+	mentions := map[string]int{}
+	for _, clause := range tokenize(text) {
+		found := map[string]bool{}
+		for i := range clause {
+			for _, term := range terms {
+				if term.matches(clause, i) {
+					found[term.aspect] = true
+				}
+			}
+		}
+		if len(found) == 0 {
+			continue
+		}
+
+		score := fallback
+		if total, _, hits := lexicon.scoreClause(clause); hits > 0 {
+			score = normalize(total)
+		}
+		for aspect := range found {
+			totals[aspect] += score
+			mentions[aspect]++
+		}
+	}
+
+	result := make([]AspectMention, 0, len(mentions))
+	for aspect, count := range mentions {
+		result = append(result, AspectMention{Aspect: aspect, Sentiment: totals[aspect] / float64(count), Mentions: count})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Aspect < result[j].Aspect })
+	return result
+}
+
+// ValidateAspectKeywords checks company keywords the way taxonomy files are read
+func ValidateAspectKeywords(keywords map[string][]string) error {
+	if len(keywords) > maxCompanyAspects {
+		return fmt.Errorf("at most %d aspects can be configured", maxCompanyAspects)
+	}
+	_, err := parseAspectTerms(keywords)
+	return err
+}
+
+func (t aspectTerm) matches(clause []token, i int) bool {
+	if i+len(t.words) > len(clause) {
+		return false
+	}
+	for j, word := range t.words {
+		tok := clause[i+j]
+		if tok.emoji {
+			return false
+		}
+		if t.stem && j == len(t.words)-1 {
+			if !strings.HasPrefix(tok.text, word) {
+				return false
+			}
+		} else if tok.text != word {
+			return false
+		}
+	}
+	return true
+}
+
+// taxonomyFor returns the terms for language together with the English ones
+func taxonomyFor(taxonomies map[string][]aspectTerm, language string) []aspectTerm {
+	terms := taxonomies["en"]
+	if language != "en" {
+		terms = append(append([]aspectTerm{}, taxonomies[language]...), terms...)
+	}
+	return terms
+}
+
+func parseAspectTerms(keywords map[string][]string) ([]aspectTerm, error) {
+	terms := []aspectTerm{}
+	for aspect, list := range keywords {
+		aspect = strings.TrimSpace(aspect)
+		if aspect == "" || strings.ContainsAny(aspect, " ,:") {
+			return nil, fmt.Errorf("invalid aspect %q", aspect)
+		}
+		if utf8.RuneCountInString(aspect) > maxAspectNameLength {
+			return nil, fmt.Errorf("aspect %q is longer than %d characters", aspect, maxAspectNameLength)
+		}
+		if len(list) > maxAspectKeywords {
+			return nil, fmt.Errorf("aspect %s has more than %d keywords", aspect, maxAspectKeywords)
+		}
+		for _, keyword := range list {
+			if utf8.RuneCountInString(keyword) > maxKeywordLength {
+				return nil, fmt.Errorf("keyword for aspect %s is longer than %d characters", aspect, maxKeywordLength)
+			}
+			words := strings.Fields(normalizeText(keyword))
+			if len(words) == 0 {
+				return nil, fmt.Errorf("empty keyword for aspect %s", aspect)
+			}
+			term := aspectTerm{aspect: aspect, words: words}
+			last := len(words) - 1
+			if stem, ok := strings.CutSuffix(words[last], "*"); ok {
+				if stem == "" {
+					return nil, fmt.Errorf("invalid keyword %q for aspect %s", keyword, aspect)
+				}
+				words[last], term.stem = stem, true
+			}
+			terms = append(terms, term)
+		}
+	}
+	return terms, nil
+}
+
+// mustLoadTaxonomies reads every file in dir, keyed by language
+func mustLoadTaxonomies(fsys embed.FS, dir string) map[string][]aspectTerm {
+	files, err := fsys.ReadDir(dir)
+	if err != nil {
+		panic(err)
+	}
+
+	loaded := map[string][]aspectTerm{}
+	for _, file := range files {
+		name := path.Join(dir, file.Name())
+		keywords, err := readTaxonomyFile(fsys, name)
+		if err != nil {
+			panic(err)
+		}
+		terms, err := parseAspectTerms(keywords)
+		if err != nil {
+			panic(fmt.Sprintf("%s: %v", name, err))
+		}
+		loaded[strings.TrimSuffix(file.Name(), path.Ext(file.Name()))] = terms
+	}
+	return loaded
+}
+
+func readTaxonomyFile(fsys embed.FS, name string) (map[string][]string, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	keywords := map[string][]string{}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		aspect, list, ok := strings.Cut(text, ":")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected <aspect>: <term>, <term>", name, line)
+		}
+		aspect = strings.TrimSpace(aspect)
+		for _, term := range strings.Split(list, ",") {
+			if term = strings.TrimSpace(term); term != "" {
+				keywords[aspect] = append(keywords[aspect], term)
+			}
+		}
+	}
+	return keywords, scanner.Err()
+}
@@ -0,0 +1,95 @@
+package sentiment
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestExtractAspects(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		language string
+		keywords map[string][]string
+		want     map[string]int // aspect to sentiment sign
+	}{
+		{"contrast splits aspects", "great team but the salary is too low and unfair", "en", nil,
+			map[string]int{AspectCulture: 1, AspectCompensation: -1}},
+		{"stems and phrases", "no promotions in two years, too much work", "en", nil,
+			map[string]int{AspectGrowth: 0, AspectWorkload: 0}},
+		{"Hindi", "टीम अच्छी है लेकिन वेतन बहुत खराब है", "hi", nil,
+			map[string]int{AspectCulture: 1, AspectCompensation: -1}},
+		{"English words in Hindi", "मैनेजर बहुत बुरा है, salary भी कम", "hi", nil,
+			map[string]int{AspectManager: -1, AspectCompensation: 0}},
+		{"company keywords", "the canteen food is terrible", "en", map[string][]string{"facilities": {"canteen", "cafeteria"}},
+			map[string]int{"facilities": -1}},
+		{"no aspects", "all good", "en", nil, map[string]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mentions := ExtractAspects(tt.text, tt.language, tt.keywords, 0)
+			if len(mentions) != len(tt.want) {
+				t.Fatalf("Expected %d aspects, got %+v", len(tt.want), mentions)
+			}
+			for _, m := range mentions {
+				sign, ok := tt.want[m.Aspect]
+				if !ok {
+					t.Errorf("Unexpected aspect %s", m.Aspect)
+					continue
+				}
+				if (sign > 0 && m.Sentiment <= 0) || (sign < 0 && m.Sentiment >= 0) {
+					t.Errorf("Expected %s sign %d, got %f", m.Aspect, sign, m.Sentiment)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractAspectsFallsBackToAnswerScore(t *testing.T) {
+	mentions := ExtractAspects("the deadline on friday", "en", nil, -0.6)
+	if len(mentions) != 1 || mentions[0].Aspect != AspectWorkload || mentions[0].Sentiment != -0.6 {
+		t.Errorf("Expected workload at the answer's -0.6, got %+v", mentions)
+	}
+}
+
+func TestValidateAspectKeywords(t *testing.T) {
+	tooMany := map[string][]string{}
+	for i := 0; i <= maxCompanyAspects; i++ {
+		tooMany[fmt.Sprintf("aspect_%d", i)] = []string{"keyword"}
+	}
+
+	tests := []struct {
+		name     string
+		keywords map[string][]string
+		wantErr  bool
+	}{
+		{"valid", map[string][]string{"facilities": {"canteen", "parking lot", "gym*"}}, false},
+		{"empty keyword", map[string][]string{"facilities": {" "}}, true},
+		{"bare star", map[string][]string{"facilities": {"*"}}, true},
+		{"aspect with spaces", map[string][]string{"free food": {"canteen"}}, true},
+		{"aspect name too long", map[string][]string{strings.Repeat("a", 51): {"canteen"}}, true},
+		{"too many keywords", map[string][]string{"facilities": make([]string, 51)}, true},
+		{"keyword too long", map[string][]string{"facilities": {strings.Repeat("a", 101)}}, true},
+		{"too many aspects", tooMany, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAspectKeywords(tt.keywords)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAspectName(t *testing.T) {
+	if name := AspectName(AspectWorkLifeBalance); name != "Work-life balance" {
+		t.Errorf("Expected Work-life balance, got %q", name)
+	}
+	if name := AspectName("office_facilities"); name != "Office facilities" {
+		t.Errorf("Expected Office facilities, got %q", name)
+	}
+}
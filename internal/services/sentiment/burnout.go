@@ -0,0 +1,119 @@
+package sentiment
+
+import (
+	"embed"
+	"fmt"
+	"math"
+)
+
+// Burnout dimensions, after the Maslach Burnout Inventory's exhaustion and cynicism
+// plus the stress and disengagement that tend to come before leaving
+const (
+	DimensionExhaustion    = "exhaustion"
+	DimensionStress        = "stress"
+	DimensionCynicism      = "cynicism"
+	DimensionDisengagement = "disengagement"
+)
+
+// BurnoutDimensions lists the dimensions in the order BurnoutScores holds them
+var BurnoutDimensions = []string{DimensionExhaustion, DimensionStress, DimensionCynicism, DimensionDisengagement}
+
+// SignalThreshold is the dimension score from which an answer is tagged with it. One
+// plain mention scores 0.5; a softened one ("a little tired") stays below.
+const SignalThreshold = 0.5
+
+// Burnout phrase files use the aspect taxonomy format, with a dimension per line
+//
+//go:embed burnout/*.txt
+var burnoutFiles embed.FS
+
+var burnoutTaxonomies = mustLoadTaxonomies(burnoutFiles, "burnout")
+
+// BurnoutScores is how strongly a text signals each burnout dimension, 0 to 1
+type BurnoutScores struct {
+	Exhaustion    float64 `json:"exhaustion"`
+	Stress        float64 `json:"stress"`
+	Cynicism      float64 `json:"cynicism"`
+	Disengagement float64 `json:"disengagement"`
+}
+
+// ClassifyBurnout finds burnout phrases in text using the phrases for language plus
+// the English ones. Mentions are weighed by the lexicon's intensifiers and dropped
+// when negated ("not tired at all"); each one halves the distance left to 1.
+func ClassifyBurnout(text, language string) BurnoutScores {
+	terms := taxonomyFor(burnoutTaxonomies, language)
+	lexicon := LexiconFor(language)
+
+	strength := map[string]float64{}
+	for _, clause := range tokenize(text) {
+		for i := range clause {
+			counted := map[string]bool{}
+			for _, term := range terms {
+				if counted[term.aspect] || !term.matches(clause, i) {
+					continue
+				}
+				counted[term.aspect] = true
+				if !lexicon.negatedSpan(clause, i, i+len(term.words)) {
+					strength[term.aspect] += lexicon.intensity(clause, i)
+				}
+			}
+		}
+	}
+
+	score := func(dimension string) float64 {
+		return 1 - math.Pow(0.5, strength[dimension])
+	}
+	return BurnoutScores{
+		Exhaustion:    score(DimensionExhaustion),
+		Stress:        score(DimensionStress),
+		Cynicism:      score(DimensionCynicism),
+		Disengagement: score(DimensionDisengagement),
+	}
+}
+
+// Values returns the scores in BurnoutDimensions order
+func (b BurnoutScores) Values() []float64 {
+	return []float64{b.Exhaustion, b.Stress, b.Cynicism, b.Disengagement}
+}
+
+// Signals lists the dimensions at or above SignalThreshold
+func (b BurnoutScores) Signals() []string {
+	signals := []string{}
+	for i, value := range b.Values() {
+		if value >= SignalThreshold {
+			signals = append(signals, BurnoutDimensions[i])
+		}
+	}
+	return signals
+}
+
+// Index combines the dimensions into one 0-1 burnout index: the chance at least one
+// of them holds, if each score is read as a probability. A single strong dimension
+// is enough to raise it, as burnout rarely shows on every dimension at once.
+func (b BurnoutScores) Index() float64 {
+	clear := 1.0
+	for _, value := range b.Values() {
+		clear *= 1 - value
+	}
+	return 1 - clear
+}
+
+// Max keeps the stronger score per dimension, to combine the answers of a response
+func (b BurnoutScores) Max(other BurnoutScores) BurnoutScores {
+	return BurnoutScores{
+		Exhaustion:    math.Max(b.Exhaustion, other.Exhaustion),
+		Stress:        math.Max(b.Stress, other.Stress),
+		Cynicism:      math.Max(b.Cynicism, other.Cynicism),
+		Disengagement: math.Max(b.Disengagement, other.Disengagement),
+	}
+}
+
+// Validate reports scores outside 0 to 1, as a model might return
+func (b BurnoutScores) Validate() error {
+	for i, value := range b.Values() {
+		if value < 0 || value > 1 {
+			return fmt.Errorf("%s %f out of range", BurnoutDimensions[i], value)
+		}
+	}
+	return nil
+}
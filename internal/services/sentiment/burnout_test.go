@@ -0,0 +1,96 @@
+package sentiment
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClassifyBurnout(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		language string
+		want     []string // signals
+	}{
+		{"exhausted", "I am completely exhausted every week", "en", []string{DimensionExhaustion}},
+		{"several dimensions", "so stressed, and honestly what's the point, nothing changes", "en", []string{DimensionStress, DimensionCynicism}},
+		{"negated", "not tired at all, I love this job", "en", []string{}},
+		{"softened", "a little tired", "en", []string{}},
+		{"phrase with its own negator", "no energy left by friday", "en", []string{DimensionExhaustion}},
+		{"Hindi", "मैं बहुत थका हुआ हूँ और काम में मन नहीं लगता", "hi", []string{DimensionExhaustion, DimensionDisengagement}},
+		{"Hindi negated", "मैं थका नहीं हूँ", "hi", []string{}},
+		{"Hinglish", "bahut tension hai, naukri chhodna chahta hu", "hi-Latn", []string{DimensionStress, DimensionDisengagement}},
+		{"Tamil", "ரொம்ப சோர்வா இருக்கு", "ta", []string{DimensionExhaustion}},
+		{"Kannada", "ತುಂಬಾ ಒತ್ತಡ ಇದೆ", "kn", []string{DimensionStress}},
+		{"English in Bengali", "অফিসে খুব burnout", "bn", []string{DimensionExhaustion}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scores := ClassifyBurnout(tt.text, tt.language)
+			if got := scores.Signals(); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected signals %v, got %v (%+v)", tt.want, got, scores)
+			}
+		})
+	}
+}
+
+func TestBurnoutScoresGrowWithMentions(t *testing.T) {
+	one := ClassifyBurnout("tired", "en").Exhaustion
+	two := ClassifyBurnout("tired and drained", "en").Exhaustion
+	if one != 0.5 || two != 0.75 {
+		t.Errorf("Expected 0.5 then 0.75, got %f and %f", one, two)
+	}
+}
+
+func TestBurnoutIndex(t *testing.T) {
+	if index := (BurnoutScores{}).Index(); index != 0 {
+		t.Errorf("Expected no burnout to index 0, got %f", index)
+	}
+	if index := (BurnoutScores{Exhaustion: 0.5}).Index(); index != 0.5 {
+		t.Errorf("Expected one dimension to carry the index, got %f", index)
+	}
+	if index := (BurnoutScores{Exhaustion: 0.5, Cynicism: 0.5}).Index(); index != 0.75 {
+		t.Errorf("Expected 0.75 for two dimensions, got %f", index)
+	}
+
+	combined := BurnoutScores{Exhaustion: 0.5, Stress: 0.2}.Max(BurnoutScores{Stress: 0.75})
+	if combined != (BurnoutScores{Exhaustion: 0.5, Stress: 0.75}) {
+		t.Errorf("Expected the stronger score per dimension, got %+v", combined)
+	}
+}
+
+func TestBurnoutFilesUseKnownDimensions(t *testing.T) {
+	known := map[string]bool{}
+	for _, dimension := range BurnoutDimensions {
+		known[dimension] = true
+	}
+	for language, terms := range burnoutTaxonomies {
+		for _, term := range terms {
+			if !known[term.aspect] {
+				t.Errorf("%s: unknown burnout dimension %q", language, term.aspect)
+			}
+		}
+	}
+	for _, language := range LexiconLanguages() {
+		if language != "en" && len(burnoutTaxonomies[language]) == 0 {
+			t.Errorf("Expected burnout phrases for %s", language)
+		}
+	}
+}
+
+func TestMLServiceClassifiesBurnoutWithLexicon(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(&fakeAnalyzer{name: "model", score: -0.5})
+	if err := registry.SetChains(Chains{DefaultChainKey: {"model"}}); err != nil {
+		t.Fatalf("Expected valid chains, got %v", err)
+	}
+
+	result, err := NewMLService(registry).AnalyzeForCompany(1, nil, "totally burnt out", "en")
+	if err != nil || result.Provider != "model" {
+		t.Fatalf("Expected the model to score, got %+v, %v", result, err)
+	}
+	if result.Burnout == nil || result.Burnout.Exhaustion < SignalThreshold {
+		t.Errorf("Expected the lexicon to fill in exhaustion, got %+v", result.Burnout)
+	}
+}
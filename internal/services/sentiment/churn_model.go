@@ -0,0 +1,120 @@
+package sentiment
+
+import (
+	"errors"
+	"math"
+)
+
+// FeatureNames labels the entries of FeatureVector, in order
+var FeatureNames = []string{"sentiment", "response", "activity", "engagement", "burnout"}
+
+var ErrInsufficientData = errors.New("not enough labelled employees to train a churn model")
+
+// FeatureVector normalizes features to the 0-1 scale used by both the default
+// weights and trained models. Higher values always mean more risk.
+func FeatureVector(features ChurnFeatures) []float64 {
+	engagement := 0.0
+	if features.TotalResponses > 0 {
+		engagement = float64(features.NegativeResponses) / float64(features.TotalResponses)
+	}
+	return []float64{
+		(1 - features.AvgSentiment) / 2,
+		1 - features.ResponseRate,
+		math.Min(float64(features.DaysInactive)/30, 1.0),
+		engagement,
+		features.BurnoutIndex,
+	}
+}
+
+// ChurnModel is a logistic regression over FeatureVector
+type ChurnModel struct {
+	Weights []float64
+	Bias    float64
+}
+
+func (m *ChurnModel) Predict(features ChurnFeatures) float64 {
+	z := m.Bias
+	for i, x := range FeatureVector(features) {
+		if i < len(m.Weights) {
+			z += m.Weights[i] * x
+		}
+	}
+	return sigmoid(z)
+}
+
+type TrainingSample struct {
+	Features ChurnFeatures
+	Left     bool
+}
+
+type TrainOptions struct {
+	LearningRate float64
+	Epochs       int
+	L2           float64 // regularization strength; the bias is not regularized
+	MinSamples   int
+	MinPerClass  int // minimum leavers and stayers
+}
+
+var DefaultTrainOptions = TrainOptions{
+	LearningRate: 0.5,
+	Epochs:       2000,
+	L2:           0.01,
+	MinSamples:   30,
+	MinPerClass:  5,
+}
+
+// TrainChurnModel fits a logistic regression with batch gradient descent. It returns
+// ErrInsufficientData when there are too few samples, or too few of either outcome,
+// for the weights to mean anything.
+func TrainChurnModel(samples []TrainingSample, opts TrainOptions) (*ChurnModel, error) {
+	leavers := 0
+	for _, s := range samples {
+		if s.Left {
+			leavers++
+		}
+	}
+	if len(samples) < opts.MinSamples || leavers < opts.MinPerClass || len(samples)-leavers < opts.MinPerClass {
+		return nil, ErrInsufficientData
+	}
+
+	xs := make([][]float64, len(samples))
+	for i, s := range samples {
+		xs[i] = FeatureVector(s.Features)
+	}
+
+	n := float64(len(samples))
+	weights := make([]float64, len(FeatureNames))
+	bias := 0.0
+
+	for epoch := 0; epoch < opts.Epochs; epoch++ {
+		gradW := make([]float64, len(weights))
+		gradB := 0.0
+
+		for i, x := range xs {
+			z := bias
+			for j := range weights {
+				z += weights[j] * x[j]
+			}
+			y := 0.0
+			if samples[i].Left {
+				y = 1.0
+			}
+			diff := sigmoid(z) - y
+			for j := range weights {
+				gradW[j] += diff * x[j]
+			}
+			gradB += diff
+		}
+
+		for j := range weights {
+			weights[j] -= opts.LearningRate * (gradW[j]/n + opts.L2*weights[j])
+		}
+		bias -= opts.LearningRate * gradB / n
+	}
+
+	return &ChurnModel{Weights: weights, Bias: bias}, nil
+}
+
+func sigmoid(z float64) float64 {
+	return 1 / (1 + math.Exp(-z))
+}
@@ -0,0 +1,91 @@
+package sentiment
+
+import (
+	"errors"
+	"testing"
+)
+
+// syntheticSamples builds employees where leavers are disengaged and stayers are not
+func syntheticSamples(n int) []TrainingSample {
+	samples := []TrainingSample{}
+	for i := 0; i < n; i++ {
+		spread := float64(i%5) / 10
+		samples = append(samples,
+			TrainingSample{
+				Features: ChurnFeatures{AvgSentiment: -0.4 - spread, ResponseRate: 0.3, DaysInactive: 20 + i%10, NegativeResponses: 6, TotalResponses: 8},
+				Left:     true,
+			},
+			TrainingSample{
+				Features: ChurnFeatures{AvgSentiment: 0.4 + spread, ResponseRate: 0.9, DaysInactive: i % 5, NegativeResponses: 1, TotalResponses: 8},
+				Left:     false,
+			},
+		)
+	}
+	return samples
+}
+
+func TestTrainChurnModel(t *testing.T) {
+	model, err := TrainChurnModel(syntheticSamples(20), DefaultTrainOptions)
+	if err != nil {
+		t.Fatalf("TrainChurnModel failed: %v", err)
+	}
+
+	if len(model.Weights) != len(FeatureNames) {
+		t.Fatalf("Expected %d weights, got %d", len(FeatureNames), len(model.Weights))
+	}
+
+	leaver := ChurnFeatures{AvgSentiment: -0.6, ResponseRate: 0.2, DaysInactive: 25, NegativeResponses: 7, TotalResponses: 8}
+	stayer := ChurnFeatures{AvgSentiment: 0.6, ResponseRate: 0.95, DaysInactive: 2, NegativeResponses: 0, TotalResponses: 8}
+
+	if risk := model.Predict(leaver); risk < 0.7 {
+		t.Errorf("Expected high risk for disengaged employee, got %f", risk)
+	}
+	if risk := model.Predict(stayer); risk > 0.3 {
+		t.Errorf("Expected low risk for engaged employee, got %f", risk)
+	}
+}
+
+func TestTrainChurnModelInsufficientData(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []TrainingSample
+	}{
+		{name: "Too few samples", samples: syntheticSamples(5)},
+		{name: "No leavers", samples: stayersOnly(syntheticSamples(30))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := TrainChurnModel(tt.samples, DefaultTrainOptions)
+			if !errors.Is(err, ErrInsufficientData) {
+				t.Errorf("Expected ErrInsufficientData, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPredictorUsesModel(t *testing.T) {
+	features := ChurnFeatures{AvgSentiment: 0.5, ResponseRate: 0.9, DaysInactive: 1, TotalResponses: 5}
+
+	// A model that only looks at the bias always predicts the same risk
+	model := &ChurnModel{Weights: []float64{0, 0, 0, 0}, Bias: 0}
+	predictor := NewChurnPredictor().WithModel(model)
+
+	if risk := predictor.PredictChurnRisk(features); risk != 0.5 {
+		t.Errorf("Expected trained model risk 0.5, got %f", risk)
+	}
+
+	if risk := NewChurnPredictor().PredictChurnRisk(features); risk == 0.5 {
+		t.Errorf("Expected default weights without a model, got %f", risk)
+	}
+}
+
+func stayersOnly(samples []TrainingSample) []TrainingSample {
+	result := []TrainingSample{}
+	for _, s := range samples {
+		if !s.Left {
+			result = append(result, s)
+		}
+	}
+	return result
+}
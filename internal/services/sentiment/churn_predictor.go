@@ -11,62 +11,155 @@ type ChurnFeatures struct {
 	NegativeResponses int
 	TotalResponses   int
 	LastLoginDays    int
+	BurnoutIndex     float64 // 0 to 1, averaged over responses with free text
 }
 
-type ChurnPredictor struct{}
+// RiskConfig is a company's tuning of the default scoring and of GetRiskFactors
+type RiskConfig struct {
+	Weights []float64 // in FeatureNames order, summing to 1
+
+	// A feature is reported as a risk factor beyond its cutoff
+	LowSentiment     float64
+	LowResponseRate  float64
+	InactiveDays     int
+	NegativeRatio    float64
+	InfrequentLogins int
+	HighBurnout      float64
+}
+
+// DefaultRiskConfig uses weights based on HR research
+var DefaultRiskConfig = RiskConfig{
+	Weights: []float64{
+		0.40, // sentiment: increased weight for sentiment
+		0.15, // response
+		0.15, // activity
+		0.15, // engagement
+		0.15, // burnout
+	},
+	LowSentiment:     -0.2,
+	LowResponseRate:  0.5,
+	InactiveDays:     7,
+	NegativeRatio:    0.6,
+	InfrequentLogins: 3,
+	HighBurnout:      0.5,
+}
+
+type ChurnPredictor struct {
+	model  *ChurnModel
+	config RiskConfig
+}
 
 func NewChurnPredictor() *ChurnPredictor {
-	return &ChurnPredictor{}
+	return &ChurnPredictor{config: DefaultRiskConfig}
+}
+
+// WithModel returns a predictor that scores with a trained model instead of the configured weights
+func (cp *ChurnPredictor) WithModel(model *ChurnModel) *ChurnPredictor {
+	return &ChurnPredictor{model: model, config: cp.config}
 }
 
-// Simple ML-like churn prediction using weighted features
+// WithConfig returns a predictor using a company's weights and factor cutoffs
+func (cp *ChurnPredictor) WithConfig(config RiskConfig) *ChurnPredictor {
+	return &ChurnPredictor{model: cp.model, config: config}
+}
+
+// Contribution is one feature's part in a risk score. Impact is the weight times the
+// normalized value and Share is the impact as a fraction of the total absolute impact.
+// Trained models can have negative weights, giving a negative share that lowered the score.
+type Contribution struct {
+	Feature string
+	Label   string
+	Value   float64
+	Impact  float64
+	Share   float64
+}
+
+type Prediction struct {
+	RiskScore     float64
+	Contributions []Contribution
+}
+
+// Simple ML-like churn prediction using weighted features, or the trained model if one is set
 func (cp *ChurnPredictor) PredictChurnRisk(features ChurnFeatures) float64 {
-	// Weights based on HR research
-	weights := map[string]float64{
-		"sentiment":    0.50,  // Increased weight for sentiment
-		"response":     0.15,
-		"activity":     0.15,
-		"engagement":   0.20,
-	}
-
-	// Normalize features to 0-1 scale
-	sentimentScore := (1 - features.AvgSentiment) / 2 // Convert -1,1 to 0,1
-	responseScore := 1 - features.ResponseRate
-	activityScore := math.Min(float64(features.DaysInactive)/30, 1.0)
-	
-	engagementScore := 0.0
-	if features.TotalResponses > 0 {
-		engagementScore = float64(features.NegativeResponses) / float64(features.TotalResponses)
+	return cp.Predict(features).RiskScore
+}
+
+// Predict scores features and explains how much each one contributed
+func (cp *ChurnPredictor) Predict(features ChurnFeatures) Prediction {
+	values := FeatureVector(features)
+
+	if cp.model != nil {
+		return Prediction{
+			RiskScore:     cp.model.Predict(features),
+			Contributions: contributions(values, cp.model.Weights),
+		}
 	}
 
 	// Calculate weighted risk score
-	riskScore := (sentimentScore * weights["sentiment"]) +
-		(responseScore * weights["response"]) +
-		(activityScore * weights["activity"]) +
-		(engagementScore * weights["engagement"])
+	riskScore := 0.0
+	for i, value := range values {
+		if i < len(cp.config.Weights) {
+			riskScore += value * cp.config.Weights[i]
+		}
+	}
 
 	// Apply sigmoid for smooth 0-1 output (increased sensitivity)
-	return 1 / (1 + math.Exp(-8*(riskScore-0.4)))
+	return Prediction{
+		RiskScore:     sigmoid(8 * (riskScore - 0.4)),
+		Contributions: contributions(values, cp.config.Weights),
+	}
+}
+
+var featureLabels = map[string]string{
+	"sentiment":  "Survey sentiment",
+	"response":   "Survey response rate",
+	"activity":   "Inactivity",
+	"engagement": "Negative response ratio",
+	"burnout":    "Burnout signals",
+}
+
+func contributions(values, weights []float64) []Contribution {
+	result := make([]Contribution, len(FeatureNames))
+	total := 0.0
+	for i, name := range FeatureNames {
+		weight := 0.0
+		if i < len(weights) {
+			weight = weights[i]
+		}
+		result[i] = Contribution{Feature: name, Label: featureLabels[name], Value: values[i], Impact: values[i] * weight}
+		total += math.Abs(result[i].Impact)
+	}
+
+	if total > 0 {
+		for i := range result {
+			result[i].Share = result[i].Impact / total
+		}
+	}
+	return result
 }
 
 func (cp *ChurnPredictor) GetRiskFactors(features ChurnFeatures) []string {
 	factors := []string{}
+	cutoffs := cp.config
 
-	if features.AvgSentiment < -0.2 {
+	if features.AvgSentiment < cutoffs.LowSentiment {
 		factors = append(factors, "Low sentiment scores")
 	}
-	if features.ResponseRate < 0.5 {
+	if features.ResponseRate < cutoffs.LowResponseRate {
 		factors = append(factors, "Poor survey participation")
 	}
-	if features.DaysInactive > 7 {
+	if features.DaysInactive > cutoffs.InactiveDays {
 		factors = append(factors, "Reduced activity")
 	}
-	if features.TotalResponses > 0 && float64(features.NegativeResponses)/float64(features.TotalResponses) > 0.6 {
+	if features.TotalResponses > 0 && float64(features.NegativeResponses)/float64(features.TotalResponses) > cutoffs.NegativeRatio {
 		factors = append(factors, "Frequent negative feedback")
 	}
-	if features.LastLoginDays > 3 {
+	if features.LastLoginDays > cutoffs.InfrequentLogins {
 		factors = append(factors, "Infrequent system usage")
 	}
+	if features.BurnoutIndex > cutoffs.HighBurnout {
+		factors = append(factors, "Burnout signals in survey answers")
+	}
 
 	return factors
 }
@@ -79,18 +172,21 @@ func (cp *ChurnPredictor) GenerateRetentionSuggestions(features ChurnFeatures, l
 			"response":  {"Send personalized survey", "Improve communication", "Regular check-ins"},
 			"activity":  {"Assign engaging projects", "Team collaboration", "Skill development"},
 			"engagement": {"Recognition program", "Flexible work options", "Mentorship"},
+			"burnout":   {"Review workload and deadlines", "Encourage time off", "Offer wellbeing support"},
 		},
 		"hi": {
 			"sentiment": {"व्यक्तिगत बैठक करें", "करियर लक्ष्यों पर चर्चा", "कार्य संबंधी चिंताओं को हल करें"},
 			"response":  {"व्यक्तिगत सर्वे भेजें", "संवाद में सुधार", "नियमित जांच"},
 			"activity":  {"रोचक प्रोजेक्ट दें", "टीम सहयोग", "कौशल विकास"},
 			"engagement": {"पहचान कार्यक्रम", "लचीले काम के विकल्प", "मार्गदर्शन"},
+			"burnout":   {"काम के बोझ और समय-सीमा की समीक्षा", "छुट्टी लेने को प्रोत्साहित करें", "स्वास्थ्य सहायता दें"},
 		},
 		"ta": {
 			"sentiment": {"தனிப்பட்ட சந்திப்பு", "தொழில் இலக்குகள் விவாதம்", "வேலை கவலைகள் தீர்க்க"},
 			"response":  {"தனிப்பட்ட கணக்கெடுப்பு", "தொடர்பு மேம்படுத்த", "வழக்கமான சரிபார்ப்பு"},
 			"activity":  {"சுவாரஸ்யமான திட்டங்கள்", "குழு ஒத்துழைப்பு", "திறன் மேம்பாடு"},
 			"engagement": {"அங்கீகார திட்டம்", "நெகிழ்வான வேலை", "வழிகாட்டுதல்"},
+			"burnout":   {"வேலைப்பளு மறுஆய்வு", "விடுப்பு எடுக்க ஊக்குவிக்க", "நல்வாழ்வு ஆதரவு"},
 		},
 	}
 
@@ -100,19 +196,23 @@ func (cp *ChurnPredictor) GenerateRetentionSuggestions(features ChurnFeatures, l
 	}
 
 	result := []string{}
+	cutoffs := cp.config
 	
-	if features.AvgSentiment < -0.2 {
+	if features.AvgSentiment < cutoffs.LowSentiment {
 		result = append(result, suggestions[lang]["sentiment"]...)
 	}
-	if features.ResponseRate < 0.5 {
+	if features.ResponseRate < cutoffs.LowResponseRate {
 		result = append(result, suggestions[lang]["response"]...)
 	}
-	if features.DaysInactive > 7 {
+	if features.DaysInactive > cutoffs.InactiveDays {
 		result = append(result, suggestions[lang]["activity"]...)
 	}
-	if features.TotalResponses > 0 && float64(features.NegativeResponses)/float64(features.TotalResponses) > 0.6 {
+	if features.TotalResponses > 0 && float64(features.NegativeResponses)/float64(features.TotalResponses) > cutoffs.NegativeRatio {
 		result = append(result, suggestions[lang]["engagement"]...)
 	}
+	if features.BurnoutIndex > cutoffs.HighBurnout {
+		result = append(result, suggestions[lang]["burnout"]...)
+	}
 
 	// Limit to top 4 suggestions
 	if len(result) > 4 {
@@ -1,6 +1,9 @@
 package sentiment
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestPredictChurnRisk(t *testing.T) {
 	predictor := NewChurnPredictor()
@@ -53,6 +56,73 @@ func TestPredictChurnRisk(t *testing.T) {
 	}
 }
 
+func TestPredictContributions(t *testing.T) {
+	features := ChurnFeatures{
+		AvgSentiment:      -0.8,
+		ResponseRate:      1.0,
+		DaysInactive:      0,
+		NegativeResponses: 2,
+		TotalResponses:    10,
+	}
+
+	prediction := NewChurnPredictor().Predict(features)
+
+	if prediction.RiskScore != NewChurnPredictor().PredictChurnRisk(features) {
+		t.Errorf("Expected Predict and PredictChurnRisk to agree")
+	}
+	if len(prediction.Contributions) != len(FeatureNames) {
+		t.Fatalf("Expected %d contributions, got %d", len(FeatureNames), len(prediction.Contributions))
+	}
+
+	total := 0.0
+	for _, c := range prediction.Contributions {
+		total += c.Share
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("Expected shares to sum to 1, got %f", total)
+	}
+
+	sentiment := prediction.Contributions[0]
+	if sentiment.Feature != "sentiment" || sentiment.Share < 0.8 {
+		t.Errorf("Expected sentiment to dominate the score, got %+v", sentiment)
+	}
+	if response := prediction.Contributions[1]; response.Share != 0 {
+		t.Errorf("Expected full participation to contribute nothing, got %f", response.Share)
+	}
+}
+
+func TestPredictContributionsWithModel(t *testing.T) {
+	model := &ChurnModel{Weights: []float64{2, -1, 0, 0}}
+	features := ChurnFeatures{AvgSentiment: -1, ResponseRate: 0}
+
+	contributions := NewChurnPredictor().WithModel(model).Predict(features).Contributions
+
+	if contributions[0].Share != 2.0/3 || contributions[1].Share != -1.0/3 {
+		t.Errorf("Expected signed shares 2/3 and -1/3, got %f and %f", contributions[0].Share, contributions[1].Share)
+	}
+}
+
+func TestPredictorWithConfig(t *testing.T) {
+	features := ChurnFeatures{AvgSentiment: -0.1, ResponseRate: 1.0, DaysInactive: 20}
+
+	config := DefaultRiskConfig
+	config.Weights = []float64{0.2, 0.1, 0.6, 0.1}
+	config.LowSentiment = 0
+	configured := NewChurnPredictor().WithConfig(config)
+
+	if configured.PredictChurnRisk(features) <= NewChurnPredictor().PredictChurnRisk(features) {
+		t.Errorf("Expected a heavier inactivity weight to raise the risk of an inactive employee")
+	}
+
+	factors := configured.GetRiskFactors(features)
+	if len(factors) != 2 || factors[0] != "Low sentiment scores" {
+		t.Errorf("Expected the raised sentiment cutoff to report low sentiment, got %v", factors)
+	}
+	if factors := NewChurnPredictor().GetRiskFactors(features); len(factors) != 1 {
+		t.Errorf("Expected only reduced activity with default cutoffs, got %v", factors)
+	}
+}
+
 func TestGetRiskFactors(t *testing.T) {
 	predictor := NewChurnPredictor()
 
@@ -113,4 +183,23 @@ func TestGenerateRetentionSuggestions(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestBurnoutRaisesRisk(t *testing.T) {
+	predictor := NewChurnPredictor()
+	calm := ChurnFeatures{AvgSentiment: 0, ResponseRate: 1.0, TotalResponses: 4}
+	burntOut := calm
+	burntOut.BurnoutIndex = 0.9
+
+	if predictor.PredictChurnRisk(burntOut) <= predictor.PredictChurnRisk(calm) {
+		t.Errorf("Expected burnout signals to raise the risk")
+	}
+
+	factors := predictor.GetRiskFactors(burntOut)
+	if len(factors) != 1 || factors[0] != "Burnout signals in survey answers" {
+		t.Errorf("Expected only the burnout factor, got %v", factors)
+	}
+	suggestions := predictor.GenerateRetentionSuggestions(burntOut, "en")
+	if len(suggestions) == 0 || suggestions[0] != "Review workload and deadlines" {
+		t.Errorf("Expected burnout suggestions, got %v", suggestions)
+	}
+}
@@ -0,0 +1,22 @@
+package sentiment
+
+import (
+	"strings"
+
+	"github.com/VinVorteX/NoBurn/internal/services/langid"
+)
+
+// romanizedSuffix marks the lexicons for Indian languages written in Latin script,
+// e.g. "hi-Latn" for Hinglish
+const romanizedSuffix = "-Latn"
+
+// IsRomanized reports whether language is a Latin-script variant such as "hi-Latn"
+func IsRomanized(language string) bool {
+	return strings.HasSuffix(language, romanizedSuffix)
+}
+
+// DetectLanguage picks the language to score text in with langid. fallback, the
+// response's or company's language, settles close calls and text without words.
+func DetectLanguage(text, fallback string) string {
+	return langid.Detect(text, fallback)
+}
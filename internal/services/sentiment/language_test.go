@@ -0,0 +1,51 @@
+package sentiment
+
+import "testing"
+
+func TestRomanizedLexicons(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		language string
+		want     int
+	}{
+		{"Hinglish negated", "bilkul khush nahi hu", "hi-Latn", -1},
+		{"Hinglish positive", "team bahut acchi hai", "hi-Latn", 1},
+		{"Hinglish bad means later", "bad mein baat karte hain", "hi-Latn", 0},
+		{"Hinglish keeps English", "manager is very rude", "hi-Latn", -1},
+		{"Tanglish negated", "vela nalla illa", "ta-Latn", -1},
+		{"Tanglish positive", "team semma", "ta-Latn", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := LexiconFor(tt.language).Score(tt.text).Score
+			if (tt.want > 0 && score <= 0) || (tt.want < 0 && score >= 0) || (tt.want == 0 && score != 0) {
+				t.Errorf("Expected sign %d, got %f", tt.want, score)
+			}
+		})
+	}
+}
+
+func TestMLServiceRoutesByDetectedLanguage(t *testing.T) {
+	model := &fakeAnalyzer{name: "model", score: 0.9}
+	registry := NewRegistry()
+	registry.Register(model)
+	if err := registry.SetChains(Chains{DefaultChainKey: {"model"}}); err != nil {
+		t.Fatalf("Expected valid chains, got %v", err)
+	}
+	service := NewMLService(registry)
+
+	result, err := service.AnalyzeForCompany(1, nil, "ತುಂಬಾ ಒತ್ತಡ ಇದೆ", "en")
+	if err != nil || result.Language != "kn" || result.Provider != "model" {
+		t.Errorf("Expected Kannada text to reach the model as kn, got %+v (%v)", result, err)
+	}
+
+	result, err = service.AnalyzeForCompany(1, nil, "bilkul khush nahi hu yaar", "en")
+	if err != nil || result.Language != "hi-Latn" || result.Provider != ProviderLexicon || result.Score >= 0 {
+		t.Errorf("Expected Hinglish to be scored negative by the lexicon, got %+v (%v)", result, err)
+	}
+	if model.calls != 1 {
+		t.Errorf("Expected the model to be skipped for romanized text, got %d calls", model.calls)
+	}
+}
@@ -0,0 +1,395 @@
+package sentiment
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Lexicon files are whitespace separated, one entry per line, # starts a comment:
+//
+//	good 2                 sentiment term with a weight from -3 to 3
+//	frustrat* -2.5         a trailing * matches any word starting with the stem
+//	!neg not               negator flipping the terms that follow it
+//	!neg नहीं both          negator position: before (default), after or both
+//	!int very 1.5          intensifier (>1) or downtoner (<1) scaling the next term
+//	!include en            also use another lexicon's entries; a weight of 0 masks one
+//
+//go:embed lexicons/*.txt
+var lexiconFiles embed.FS
+
+const (
+	// negationScalar flips and dampens a negated term, so "not good" is milder than "bad"
+	negationScalar = -0.74
+	// negationWindow is how many words away a negator still reaches a term
+	negationWindow = 3
+	// normalizationAlpha bounds the summed weights to (-1, 1) the way VADER does
+	normalizationAlpha = 15
+)
+
+type negation int
+
+const (
+	negatesBefore negation = iota // "not good"
+	negatesAfter                  // "अच्छा नहीं", "நல்லது இல்லை"
+	negatesBoth
+)
+
+type stemTerm struct {
+	stem   string
+	weight float64
+}
+
+// Lexicon scores text in one language from weighted terms, negators and intensifiers
+type Lexicon struct {
+	Language     string
+	terms        map[string]float64
+	stems        []stemTerm // longest first
+	negators     map[string]negation
+	intensifiers map[string]float64
+	includes     []string
+}
+
+// emoji.txt is shared by every language
+var lexicons, emoji = mustLoadLexicons()
+
+// LexiconFor returns the lexicon for language, or the English one when there is none
+func LexiconFor(language string) *Lexicon {
+	if lexicon, ok := lexicons[language]; ok {
+		return lexicon
+	}
+	return lexicons["en"]
+}
+
+// LexiconLanguages lists the languages with their own lexicon
+func LexiconLanguages() []string {
+	languages := make([]string, 0, len(lexicons))
+	for language := range lexicons {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Score sums the weights of the sentiment terms and emoji in text. A negator within
+// negationWindow words of a term in the same clause flips it, and intensifiers right
+// before it scale it. Confidence grows with the number of terms found and drops when
+// they disagree; text without any scores 0 at low confidence.
+func (l *Lexicon) Score(text string) Result {
+	total, magnitude, hits := 0.0, 0.0, 0
+	for _, clause := range tokenize(text) {
+		t, m, h := l.scoreClause(clause)
+		total, magnitude, hits = total+t, magnitude+m, hits+h
+	}
+
+	if hits == 0 {
+		return Result{Score: 0, Confidence: 0.2}
+	}
+	agreement := math.Abs(total) / magnitude
+	coverage := math.Min(float64(hits), 3) / 3
+	return Result{
+		Score:      normalize(total),
+		Confidence: 0.4 + 0.5*agreement*coverage,
+	}
+}
+
+// scoreClause returns the summed weights of a clause's terms, the sum of their
+// magnitudes and how many there were
+func (l *Lexicon) scoreClause(clause []token) (total, magnitude float64, hits int) {
+	for i, tok := range clause {
+		weight, ok := 0.0, false
+		if tok.emoji {
+			weight, ok = emoji[tok.text]
+		} else if weight, ok = l.weight(tok.text); ok {
+			weight *= l.intensity(clause, i)
+			if l.negated(clause, i) {
+				weight *= negationScalar
+			}
+		}
+		if !ok || weight == 0 {
+			continue
+		}
+		total += weight
+		magnitude += math.Abs(weight)
+		hits++
+	}
+	return total, magnitude, hits
+}
+
+// normalize maps summed weights to (-1, 1)
+func normalize(total float64) float64 {
+	return total / math.Sqrt(total*total+normalizationAlpha)
+}
+
+func (l *Lexicon) weight(word string) (float64, bool) {
+	if weight, ok := l.terms[word]; ok {
+		return weight, true
+	}
+	for _, s := range l.stems {
+		if strings.HasPrefix(word, s.stem) {
+			return s.weight, true
+		}
+	}
+	return 0, false
+}
+
+// intensity multiplies the intensifiers directly before the term at i, so
+// "very very good" counts more than "very good"
+func (l *Lexicon) intensity(clause []token, i int) float64 {
+	scale := 1.0
+	for j := i - 1; j >= 0 && j >= i-2; j-- {
+		factor, ok := l.intensifiers[clause[j].text]
+		if !ok {
+			break
+		}
+		scale *= factor
+	}
+	return scale
+}
+
+func (l *Lexicon) negated(clause []token, i int) bool {
+	return l.negatedSpan(clause, i, i+1)
+}
+
+// negatedSpan looks for negators around the words clause[start:end], so a phrase
+// like "no energy" isn't negated by its own words
+func (l *Lexicon) negatedSpan(clause []token, start, end int) bool {
+	for j := start - 1; j >= 0 && j >= start-negationWindow; j-- {
+		if n, ok := l.negators[clause[j].text]; ok && n != negatesAfter {
+			return true
+		}
+	}
+	for j := end; j < len(clause) && j < end+negationWindow; j++ {
+		if n, ok := l.negators[clause[j].text]; ok && n != negatesBefore {
+			return true
+		}
+	}
+	return false
+}
+
+func mustLoadLexicons() (map[string]*Lexicon, map[string]float64) {
+	files, err := lexiconFiles.ReadDir("lexicons")
+	if err != nil {
+		panic(err)
+	}
+
+	loaded := map[string]*Lexicon{}
+	for _, file := range files {
+		language := strings.TrimSuffix(file.Name(), path.Ext(file.Name()))
+		lexicon, err := loadLexicon(language, path.Join("lexicons", file.Name()))
+		if err != nil {
+			panic(err)
+		}
+		loaded[language] = lexicon
+	}
+	for _, lexicon := range loaded {
+		for _, name := range lexicon.includes {
+			included, ok := loaded[name]
+			if !ok || len(included.includes) > 0 {
+				panic(fmt.Sprintf("lexicon %s: cannot include %q", lexicon.Language, name))
+			}
+			lexicon.merge(included)
+		}
+	}
+	emoji := loaded["emoji"].terms
+	delete(loaded, "emoji")
+	return loaded, emoji
+}
+
+func loadLexicon(language, name string) (*Lexicon, error) {
+	f, err := lexiconFiles.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	lexicon := &Lexicon{
+		Language:     language,
+		terms:        map[string]float64{},
+		negators:     map[string]negation{},
+		intensifiers: map[string]float64{},
+	}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(normalizeText(text))
+		if len(fields) == 0 {
+			continue
+		}
+		if err := lexicon.add(fields); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", name, line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(lexicon.stems, func(i, j int) bool {
+		return len(lexicon.stems[i].stem) > len(lexicon.stems[j].stem)
+	})
+	return lexicon, nil
+}
+
+func (l *Lexicon) add(fields []string) error {
+	switch fields[0] {
+	case "!neg":
+		if len(fields) < 2 || len(fields) > 3 {
+			return fmt.Errorf("expected !neg <word> [before|after|both]")
+		}
+		position := negatesBefore
+		if len(fields) == 3 {
+			switch fields[2] {
+			case "before":
+			case "after":
+				position = negatesAfter
+			case "both":
+				position = negatesBoth
+			default:
+				return fmt.Errorf("unknown negator position %q", fields[2])
+			}
+		}
+		l.negators[fields[1]] = position
+	case "!int":
+		if len(fields) != 3 {
+			return fmt.Errorf("expected !int <word> <multiplier>")
+		}
+		factor, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil || factor <= 0 {
+			return fmt.Errorf("invalid multiplier %q", fields[2])
+		}
+		l.intensifiers[fields[1]] = factor
+	case "!include":
+		if len(fields) != 2 {
+			return fmt.Errorf("expected !include <language>")
+		}
+		l.includes = append(l.includes, fields[1])
+	default:
+		if len(fields) != 2 {
+			return fmt.Errorf("expected <term> <weight>")
+		}
+		weight, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || weight < -3 || weight > 3 {
+			return fmt.Errorf("invalid weight %q", fields[1])
+		}
+		if stem, ok := strings.CutSuffix(fields[0], "*"); ok {
+			l.stems = append(l.stems, stemTerm{stem: stem, weight: weight})
+		} else {
+			l.terms[fields[0]] = weight
+		}
+	}
+	return nil
+}
+
+// merge adds the entries of other that l doesn't define itself
+func (l *Lexicon) merge(other *Lexicon) {
+	for word, weight := range other.terms {
+		if _, ok := l.terms[word]; !ok {
+			l.terms[word] = weight
+		}
+	}
+	for word, position := range other.negators {
+		if _, ok := l.negators[word]; !ok {
+			l.negators[word] = position
+		}
+	}
+	for word, factor := range other.intensifiers {
+		if _, ok := l.intensifiers[word]; !ok {
+			l.intensifiers[word] = factor
+		}
+	}
+	l.stems = append(l.stems, other.stems...)
+	sort.SliceStable(l.stems, func(i, j int) bool {
+		return len(l.stems[i].stem) > len(l.stems[j].stem)
+	})
+}
+
+// contrastWords start a new clause, so "not bad but slow" doesn't negate "slow" and
+// the two halves can be about different aspects
+var contrastWords = map[string]bool{
+	"but": true, "however": true, "although": true, "though": true, "whereas": true,
+	"lekin": true, "magar": true, "लेकिन": true, "मगर": true, "किंतु": true, "परंतु": true,
+	"aana": true, "ஆனால்": true, "ஆனா": true,
+	"पण": true, "কিন্তু": true, "కానీ": true, "ಆದರೆ": true, "പക്ഷേ": true, "પરંતુ": true,
+}
+
+type token struct {
+	text  string
+	emoji bool
+}
+
+// indicNormalizer maps characters that have two encodings to one of them: precomposed
+// nukta letters to base + nukta, atomic Malayalam chillus to consonant + virama, and
+// two-part Tamil, Bengali and Malayalam vowel signs to their single code point
+var indicNormalizer = strings.NewReplacer(
+	// Devanagari nukta letters
+	"\u0958", "\u0915\u093C", "\u0959", "\u0916\u093C", "\u095A", "\u0917\u093C", "\u095B", "\u091C\u093C",
+	"\u095C", "\u0921\u093C", "\u095D", "\u0922\u093C", "\u095E", "\u092B\u093C", "\u095F", "\u092F\u093C",
+	// Bengali nukta letters
+	"\u09DC", "\u09A1\u09BC", "\u09DD", "\u09A2\u09BC", "\u09DF", "\u09AF\u09BC",
+	// Malayalam chillus
+	"\u0D7A", "\u0D23\u0D4D", "\u0D7B", "\u0D28\u0D4D", "\u0D7C", "\u0D30\u0D4D", "\u0D7D", "\u0D32\u0D4D", "\u0D7E", "\u0D33\u0D4D",
+	// Two-part vowel signs
+	"\u0BC6\u0BBE", "\u0BCA", "\u0BC7\u0BBE", "\u0BCB", "\u0BC6\u0BD7", "\u0BCC",
+	"\u09C7\u09BE", "\u09CB", "\u09C7\u09D7", "\u09CC",
+	"\u0D46\u0D3E", "\u0D4A", "\u0D47\u0D3E", "\u0D4B", "\u0D46\u0D57", "\u0D4C",
+	"\u2019", "'",
+)
+
+func normalizeText(text string) string {
+	return indicNormalizer.Replace(strings.ToLower(text))
+}
+
+// tokenize splits text into clauses of words and emoji. Words keep their combining
+// marks, so Indic vowel signs and viramas stay attached; zero-width joiners and emoji
+// variation selectors are dropped. Sentence punctuation, including the danda, and
+// contrastWords end a clause and with it the reach of negators.
+func tokenize(text string) [][]token {
+	clauses := [][]token{}
+	clause := []token{}
+	word := strings.Builder{}
+
+	endClause := func() {
+		if len(clause) > 0 {
+			clauses = append(clauses, clause)
+			clause = []token{}
+		}
+	}
+	flushWord := func() {
+		w := strings.Trim(word.String(), "'")
+		word.Reset()
+		switch {
+		case contrastWords[w]:
+			endClause()
+		case w != "":
+			clause = append(clause, token{text: w})
+		}
+	}
+	flushClause := func() {
+		flushWord()
+		endClause()
+	}
+
+	for _, r := range normalizeText(text) {
+		switch {
+		case r == '\u200C' || r == '\u200D' || r == '\uFE0E' || r == '\uFE0F' || (r >= 0x1F3FB && r <= 0x1F3FF):
+			// joiners, variation selectors and skin tones
+		case unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r) || (r == '\'' && word.Len() > 0):
+			word.WriteRune(r)
+		case unicode.Is(unicode.So, r):
+			flushWord()
+			clause = append(clause, token{text: string(r), emoji: true})
+		case strings.ContainsRune(".,!?;:\n।॥", r):
+			flushClause()
+		default:
+			flushWord()
+		}
+	}
+	flushClause()
+	return clauses
+}
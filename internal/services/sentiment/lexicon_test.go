@@ -0,0 +1,104 @@
+package sentiment
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLexiconScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		language string
+		want     int // sign of the score
+	}{
+		{"English positive", "The team is great", "en", 1},
+		{"English negation", "My manager is not good", "en", -1},
+		{"English contraction", "I don't enjoy the work anymore", "en", -1},
+		{"Negated negative", "There are no problems", "en", 1},
+		{"Stem match", "Constantly frustrated and overworked", "en", -1},
+		{"Clause ends negation", "Not sure. The team is great", "en", 1},
+		{"Hindi negator after", "काम अच्छा नहीं है", "hi", -1},
+		{"Hindi intensifier", "मैं बहुत खुश हूं", "hi", 1},
+		{"Tamil positive", "வேலை மிகவும் அருமையாக இருக்கிறது", "ta", 1},
+		{"Tamil negator after", "மேலாளர் நல்லது இல்லை", "ta", -1},
+		{"Bengali", "আমি খুব ক্লান্ত", "bn", -1},
+		{"Telugu", "పని చాలా బాగుంది", "te", 1},
+		{"Marathi negator after", "वातावरण चांगले नाही", "mr", -1},
+		{"Kannada", "ತುಂಬಾ ಒತ್ತಡ ಇದೆ", "kn", -1},
+		{"Malayalam", "ടീം വളരെ നല്ലത്", "ml", 1},
+		{"Gujarati negator after", "પગાર સારો નથી", "gu", -1},
+		{"Emoji", "Monday again 😡😡", "en", -1},
+		{"Emoji with variation selector", "Thanks team ❤️", "en", 1},
+		{"Neutral", "The meeting is scheduled for tomorrow", "en", 0},
+		{"Unknown language uses English", "This is terrible", "xx", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := LexiconFor(tt.language).Score(tt.text)
+			if result.Score < -1 || result.Score > 1 {
+				t.Fatalf("Score %f out of range [-1,1]", result.Score)
+			}
+			got := 0
+			if result.Score > 0 {
+				got = 1
+			} else if result.Score < 0 {
+				got = -1
+			}
+			if got != tt.want {
+				t.Errorf("Expected sign %d, got score %f", tt.want, result.Score)
+			}
+		})
+	}
+}
+
+func TestLexiconIntensifiers(t *testing.T) {
+	en := LexiconFor("en")
+	good := en.Score("good").Score
+	if veryGood := en.Score("very good").Score; veryGood <= good {
+		t.Errorf("Expected \"very good\" (%f) above \"good\" (%f)", veryGood, good)
+	}
+	if slightlyGood := en.Score("slightly good").Score; slightlyGood >= good || slightlyGood <= 0 {
+		t.Errorf("Expected \"slightly good\" (%f) between 0 and \"good\" (%f)", slightlyGood, good)
+	}
+	if notGood, bad := en.Score("not good").Score, en.Score("bad").Score; notGood >= 0 || notGood <= bad {
+		t.Errorf("Expected \"not good\" (%f) to be milder than \"bad\" (%f)", notGood, bad)
+	}
+}
+
+func TestLexiconConfidence(t *testing.T) {
+	en := LexiconFor("en")
+	none := en.Score("the meeting is at noon").Confidence
+	one := en.Score("good").Confidence
+	several := en.Score("great team, good manager, excellent pay").Confidence
+	mixed := en.Score("great team, terrible manager").Confidence
+
+	if !(none < one && one < several) {
+		t.Errorf("Expected confidence to grow with evidence, got %f, %f, %f", none, one, several)
+	}
+	if mixed >= several {
+		t.Errorf("Expected mixed signals (%f) below agreeing ones (%f)", mixed, several)
+	}
+}
+
+func TestTokenizeNormalizesIndicText(t *testing.T) {
+	// Precomposed U+095C vs ड + nukta, and a ZWJ inside the word
+	precomposed := tokenize("थोड़ा")
+	decomposed := tokenize("थोड़‍ा")
+	if !reflect.DeepEqual(precomposed, decomposed) {
+		t.Errorf("Expected both encodings to tokenize the same, got %v and %v", precomposed, decomposed)
+	}
+
+	clauses := tokenize("खुश नहीं। बहुत अच्छा")
+	if len(clauses) != 2 || len(clauses[0]) != 2 || len(clauses[1]) != 2 {
+		t.Errorf("Expected the danda to split two clauses of two words, got %v", clauses)
+	}
+}
+
+func TestLexiconLanguages(t *testing.T) {
+	want := []string{"bn", "en", "gu", "hi", "hi-Latn", "kn", "ml", "mr", "ta", "ta-Latn", "te"}
+	if got := LexiconLanguages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected lexicons for %v, got %v", want, got)
+	}
+}
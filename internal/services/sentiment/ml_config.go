@@ -0,0 +1,60 @@
+package sentiment
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/VinVorteX/NoBurn/internal/services/mlclient"
+)
+
+const DefaultHuggingFaceURL = "https://api-inference.huggingface.co/models"
+
+// MLConfig configures the HTTP-backed sentiment providers
+type MLConfig struct {
+	HuggingFaceURL   string // base URL the model ID is appended to
+	HuggingFaceToken string
+	Models           map[string]string // model ID per language, DefaultChainKey for the rest
+	ModelServerURL   string            // empty leaves the model server unregistered
+	HTTP             mlclient.Config   // retries, circuit breaker and per-company rate limit
+}
+
+// DefaultMLConfig uses IndicBERT for Indian languages and a RoBERTa sentiment model for English
+func DefaultMLConfig() MLConfig {
+	return MLConfig{
+		HuggingFaceURL: DefaultHuggingFaceURL,
+		Models: map[string]string{
+			DefaultChainKey: "ai4bharat/indic-bert",
+			"en":            "cardiffnlp/twitter-roberta-base-sentiment-latest",
+		},
+		HTTP: mlclient.DefaultConfig(),
+	}
+}
+
+// ModelFor returns the model configured for language, or the default model
+func (c MLConfig) ModelFor(language string) string {
+	if model, ok := c.Models[language]; ok {
+		return model
+	}
+	return c.Models[DefaultChainKey]
+}
+
+// ParseModels reads a spec like "ai4bharat/indic-bert;en=cardiffnlp/twitter-roberta-base-sentiment-latest".
+// An entry without a language sets the default model.
+func ParseModels(spec string) (map[string]string, error) {
+	models := map[string]string{}
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		language, model := DefaultChainKey, entry
+		if i := strings.Index(entry, "="); i >= 0 {
+			language, model = strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+		}
+		if language == "" || model == "" || strings.Contains(model, ",") {
+			return nil, fmt.Errorf("invalid sentiment model %q", entry)
+		}
+		models[language] = model
+	}
+	return models, nil
+}
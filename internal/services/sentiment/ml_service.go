@@ -1,13 +1,13 @@
 package sentiment
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"log"
-	"net/http"
-	"time"
+	"strings"
+
+	"github.com/VinVorteX/NoBurn/internal/services/mlclient"
 )
 
 type MLRequest struct {
@@ -15,105 +15,155 @@ type MLRequest struct {
 	Language string `json:"language"`
 }
 
+// MLResponse may carry burnout scores when the model server classifies them too
 type MLResponse struct {
-	Sentiment float64 `json:"sentiment"`
-	Confidence float64 `json:"confidence"`
+	Sentiment  float64        `json:"sentiment"`
+	Confidence float64        `json:"confidence"`
+	Burnout    *BurnoutScores `json:"burnout,omitempty"`
 }
 
+// MLService scores text through the registry's provider chains
 type MLService struct {
-	apiURL string
-	client *http.Client
+	registry *Registry
 }
 
-func NewMLService(apiURL string) *MLService {
-	return &MLService{
-		apiURL: apiURL,
-		client: &http.Client{Timeout: 10 * time.Second},
-	}
+func NewMLService(registry *Registry) *MLService {
+	return &MLService{registry: registry}
 }
 
+func (s *MLService) Registry() *Registry {
+	return s.registry
+}
+
+// AnalyzeSentiment uses the configured chain for the language detected in text, with
+// language as the fallback. The call isn't counted against any company's rate limit.
 func (s *MLService) AnalyzeSentiment(text, language string) (float64, error) {
-	// Primary: Use IndicBERT for Indian languages
-	score, err := s.AnalyzeWithIndicBERT(text, language)
-	if err == nil && score != 0 {
-		return score, nil
-	}
-	
-	// Fallback: Rule-based analysis
-	return AnalyzeSentiment(text, language), nil
-}
-
-// IndicBERT for Indian language sentiment (Primary model)
-func (s *MLService) AnalyzeWithIndicBERT(text, language string) (float64, error) {
-	// Use ai4bharat/indic-bert for all Indian languages
-	model := "ai4bharat/indic-bert"
-	
-	// For English, use specialized model
-	if language == "en" {
-		model = "cardiffnlp/twitter-roberta-base-sentiment-latest"
-	}
+	result, err := s.analyze(context.Background(), nil, text, language)
+	return result.Score, err
+}
 
+// AnalyzeForCompany uses the company's own chains where it has set them and counts
+// model calls against its rate limit. language is the company's language; each text
+// is routed by the language detected in it, so Hinglish or Kannada answers in an
+// English-language company still reach the right lexicon and model.
+func (s *MLService) AnalyzeForCompany(companyID uint, chains Chains, text, language string) (Result, error) {
+	ctx := mlclient.WithCompany(context.Background(), companyID)
+	return s.analyze(ctx, chains, text, language)
+}
 
-	reqBody := map[string]interface{}{
-		"inputs": text,
+// analyze also classifies burnout with the lexicon when the provider that answered
+// didn't
+func (s *MLService) analyze(ctx context.Context, chains Chains, text, fallback string) (Result, error) {
+	language := DetectLanguage(text, fallback)
+	result, err := s.registry.Analyzer(chains, language).Analyze(ctx, text, language)
+	result.Language = language
+	if result.Burnout == nil {
+		burnout := ClassifyBurnout(text, language)
+		result.Burnout = &burnout
 	}
+	return result, err
+}
+
+// HuggingFaceAnalyzer calls the Hugging Face inference API with the model configured
+// for the language
+type HuggingFaceAnalyzer struct {
+	config MLConfig
+	http   *mlclient.Client
+}
 
-	jsonData, _ := json.Marshal(reqBody)
-	
-	req, _ := http.NewRequest("POST", 
-		fmt.Sprintf("https://api-inference.huggingface.co/models/%s", model),
-		bytes.NewBuffer(jsonData))
-	
-	req.Header.Set("Authorization", "Bearer "+s.apiURL) // apiURL stores HF token
-	req.Header.Set("Content-Type", "application/json")
+// NewHuggingFaceAnalyzer takes the client shared by every Hugging Face caller, so
+// they trip the same breaker and draw on the same company limits
+func NewHuggingFaceAnalyzer(config MLConfig, client *mlclient.Client) *HuggingFaceAnalyzer {
+	return &HuggingFaceAnalyzer{config: config, http: client}
+}
 
-	resp, err := s.client.Do(req)
-	if err != nil {
-		log.Printf("HF API error: %v", err)
-		return AnalyzeSentiment(text, language), nil
-	}
-	defer resp.Body.Close()
+func (a *HuggingFaceAnalyzer) Name() string {
+	return ProviderHuggingFace
+}
 
-	body, _ := io.ReadAll(resp.Body)
-	log.Printf("HF Response [%d]: %s", resp.StatusCode, string(body))
+func (a *HuggingFaceAnalyzer) Analyze(ctx context.Context, text, language string) (Result, error) {
+	model := a.config.ModelFor(language)
+	if model == "" {
+		return Result{}, fmt.Errorf("no model configured for %s", language)
+	}
 
-	if resp.StatusCode != http.StatusOK {
-		return AnalyzeSentiment(text, language), nil
+	url := strings.TrimRight(a.config.HuggingFaceURL, "/") + "/" + model
+	body, err := a.http.Post(ctx, url, a.config.HuggingFaceToken, map[string]interface{}{"inputs": text})
+	if err != nil {
+		return Result{}, err
 	}
 
 	var result [][]map[string]interface{}
 	if err := json.Unmarshal(body, &result); err != nil {
-		log.Printf("Parse error: %v", err)
-		return AnalyzeSentiment(text, language), nil
+		return Result{}, fmt.Errorf("unexpected response: %v", err)
+	}
+	if len(result) == 0 {
+		return Result{}, errors.New("empty response")
 	}
+	return scoreFromLabels(result[0])
+}
 
-	// Convert labels to sentiment score
-	if len(result) > 0 && len(result[0]) > 0 {
-		var maxScore float64
-		var maxLabel string
-		
-		// Find highest confidence prediction
-		for _, pred := range result[0] {
-			label, _ := pred["label"].(string)
-			score, _ := pred["score"].(float64)
-			
-			if score > maxScore {
-				maxScore = score
-				maxLabel = label
-			}
-		}
-		
-		// Convert to sentiment score
-		switch maxLabel {
-		case "LABEL_2", "POSITIVE", "positive":
-			return maxScore, nil
-		case "LABEL_0", "NEGATIVE", "negative":
-			return -maxScore, nil
-		case "LABEL_1", "NEUTRAL", "neutral":
-			return 0, nil
+// scoreFromLabels converts the highest-confidence classifier label to a sentiment score,
+// keeping the classifier's confidence
+func scoreFromLabels(predictions []map[string]interface{}) (Result, error) {
+	var maxScore float64
+	var maxLabel string
+
+	// Find highest confidence prediction
+	for _, pred := range predictions {
+		label, _ := pred["label"].(string)
+		score, _ := pred["score"].(float64)
+
+		if score > maxScore {
+			maxScore = score
+			maxLabel = label
 		}
 	}
 
-	// If no valid result, use fallback
-	return AnalyzeSentiment(text, language), nil
-}
\ No newline at end of file
+	// Convert to sentiment score
+	switch maxLabel {
+	case "LABEL_2", "POSITIVE", "positive":
+		return Result{Score: maxScore, Confidence: maxScore}, nil
+	case "LABEL_0", "NEGATIVE", "negative":
+		return Result{Score: -maxScore, Confidence: maxScore}, nil
+	case "LABEL_1", "NEUTRAL", "neutral":
+		return Result{Confidence: maxScore}, nil
+	}
+	return Result{}, fmt.Errorf("unrecognised label %q", maxLabel)
+}
+
+// ModelServerAnalyzer calls a self-hosted model server that accepts an MLRequest and
+// answers with an MLResponse
+type ModelServerAnalyzer struct {
+	url  string
+	http *mlclient.Client
+}
+
+func NewModelServerAnalyzer(config MLConfig, client *mlclient.Client) *ModelServerAnalyzer {
+	return &ModelServerAnalyzer{url: config.ModelServerURL, http: client}
+}
+
+func (a *ModelServerAnalyzer) Name() string {
+	return ProviderModelServer
+}
+
+func (a *ModelServerAnalyzer) Analyze(ctx context.Context, text, language string) (Result, error) {
+	body, err := a.http.Post(ctx, a.url, "", MLRequest{Text: text, Language: language})
+	if err != nil {
+		return Result{}, err
+	}
+
+	var result MLResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return Result{}, fmt.Errorf("unexpected response: %v", err)
+	}
+	if result.Sentiment < -1 || result.Sentiment > 1 {
+		return Result{}, fmt.Errorf("sentiment %f out of range", result.Sentiment)
+	}
+	if result.Burnout != nil {
+		if err := result.Burnout.Validate(); err != nil {
+			return Result{}, err
+		}
+	}
+	return Result{Score: result.Sentiment, Confidence: result.Confidence, Burnout: result.Burnout}, nil
+}
@@ -0,0 +1,88 @@
+package sentiment
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/services/mlclient"
+)
+
+func TestParseModels(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"Empty", "", map[string]string{}, false},
+		{"Default and language", "org/indic; en=org/english", map[string]string{DefaultChainKey: "org/indic", "en": "org/english"}, false},
+		{"Missing model", "en=", nil, true},
+		{"Several models", "en=org/a,org/b", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseModels(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestModelFor(t *testing.T) {
+	config := DefaultMLConfig()
+	if got := config.ModelFor("hi"); got != "ai4bharat/indic-bert" {
+		t.Errorf("Expected IndicBERT for Hindi, got %s", got)
+	}
+	if got := config.ModelFor("en"); got != "cardiffnlp/twitter-roberta-base-sentiment-latest" {
+		t.Errorf("Expected the English model, got %s", got)
+	}
+}
+
+func TestHuggingFaceAnalyzerUsesConfig(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if r.URL.Path != "/models/org/hindi" || r.Header.Get("Authorization") != "Bearer hf_test" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`[[{"label": "negative", "score": 0.9}, {"label": "positive", "score": 0.1}]]`))
+	}))
+	defer server.Close()
+
+	config := MLConfig{
+		HuggingFaceURL:   server.URL + "/models/",
+		HuggingFaceToken: "hf_test",
+		Models:           map[string]string{"hi": "org/hindi"},
+	}
+	client := mlclient.New(mlclient.Config{Timeout: time.Second, MaxRetries: 1, RetryBackoff: time.Millisecond})
+
+	analyzer := NewHuggingFaceAnalyzer(config, client)
+	result, err := analyzer.Analyze(context.Background(), "थका हुआ", "hi")
+	if err != nil {
+		t.Fatalf("Expected the retry to succeed, got %v", err)
+	}
+	if result.Score != -0.9 {
+		t.Errorf("Expected -0.9, got %f", result.Score)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+
+	if _, err := analyzer.Analyze(context.Background(), "text", "en"); err == nil {
+		t.Error("Expected error for a language without a model")
+	}
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"sync"
+
+	"github.com/VinVorteX/NoBurn/internal/config"
+	"github.com/VinVorteX/NoBurn/internal/services/mlclient"
+	"github.com/VinVorteX/NoBurn/internal/services/sentiment"
+)
+
+var (
+	sharedOnce      sync.Once
+	sharedSentiment *sentiment.MLService
+	sharedAI        *AIService
+	sharedErr       error
+)
+
+// SharedSentimentService returns the process-wide sentiment service built from
+// config.AppConfig. Circuit breaker and rate-limit state only work when kept across
+// requests, so handlers and the worker should use this rather than NewSentimentService.
+func SharedSentimentService() (*sentiment.MLService, error) {
+	sharedOnce.Do(initShared)
+	return sharedSentiment, sharedErr
+}
+
+// SharedAIService returns the AI service sharing the sentiment service's Hugging Face client
+func SharedAIService() (*AIService, error) {
+	sharedOnce.Do(initShared)
+	return sharedAI, sharedErr
+}
+
+func initShared() {
+	sharedSentiment, sharedAI, sharedErr = newMLServices(config.AppConfig)
+}
+
+// NewSentimentService registers the providers available in cfg and applies the
+// SENTIMENT_PROVIDERS chains. The model server is only available when ML_API_URL is set.
+func NewSentimentService(cfg *config.Config) (*sentiment.MLService, error) {
+	mlService, _, err := newMLServices(cfg)
+	return mlService, err
+}
+
+func newMLServices(cfg *config.Config) (*sentiment.MLService, *AIService, error) {
+	mlConfig, err := MLConfigFrom(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hfClient := mlclient.New(mlConfig.HTTP)
+	registry := sentiment.NewRegistry()
+	registry.Register(sentiment.NewHuggingFaceAnalyzer(mlConfig, hfClient))
+	if mlConfig.ModelServerURL != "" {
+		registry.Register(sentiment.NewModelServerAnalyzer(mlConfig, mlclient.New(mlConfig.HTTP)))
+	}
+
+	chains, err := sentiment.ParseChains(cfg.SentimentProviders)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := registry.SetChains(chains); err != nil {
+		return nil, nil, err
+	}
+	return sentiment.NewMLService(registry), NewAIService(mlConfig, hfClient), nil
+}
+
+// MLConfigFrom starts from sentiment.DefaultMLConfig and applies whatever cfg sets.
+// HUGGING_FACE_MODELS entries replace the default model for their language only.
+func MLConfigFrom(cfg *config.Config) (sentiment.MLConfig, error) {
+	mlConfig := sentiment.DefaultMLConfig()
+	mlConfig.HuggingFaceToken = cfg.HuggingFaceToken
+	mlConfig.ModelServerURL = cfg.MLAPIURL
+	mlConfig.HTTP.MaxRetries = cfg.MLMaxRetries
+	mlConfig.HTTP.BreakerThreshold = cfg.MLBreakerThreshold
+	mlConfig.HTTP.CompanyRate = cfg.MLCompanyRateLimit
+	if cfg.HuggingFaceURL != "" {
+		mlConfig.HuggingFaceURL = cfg.HuggingFaceURL
+	}
+	if cfg.MLTimeout > 0 {
+		mlConfig.HTTP.Timeout = cfg.MLTimeout
+	}
+	if cfg.MLRetryBackoff > 0 {
+		mlConfig.HTTP.RetryBackoff = cfg.MLRetryBackoff
+	}
+	if cfg.MLMaxRetryWait > 0 {
+		mlConfig.HTTP.MaxRetryWait = cfg.MLMaxRetryWait
+	}
+	if cfg.MLBreakerCooldown > 0 {
+		mlConfig.HTTP.BreakerCooldown = cfg.MLBreakerCooldown
+	}
+	if cfg.MLCompanyBurst > 0 {
+		mlConfig.HTTP.CompanyBurst = cfg.MLCompanyBurst
+	}
+
+	models, err := sentiment.ParseModels(cfg.HuggingFaceModels)
+	if err != nil {
+		return mlConfig, err
+	}
+	for language, model := range models {
+		mlConfig.Models[language] = model
+	}
+	return mlConfig, nil
+}
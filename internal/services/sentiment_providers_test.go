@@ -0,0 +1,52 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/config"
+)
+
+func TestMLConfigFrom(t *testing.T) {
+	cfg := &config.Config{
+		HuggingFaceToken:   "hf_test",
+		HuggingFaceURL:     "http://localhost:9000/models",
+		HuggingFaceModels:  "en=org/english",
+		MLAPIURL:           "http://localhost:5000",
+		MLTimeout:          3 * time.Second,
+		MLMaxRetries:       1,
+		MLCompanyRateLimit: 30,
+	}
+
+	mlConfig, err := MLConfigFrom(cfg)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mlConfig.HuggingFaceURL != cfg.HuggingFaceURL || mlConfig.HuggingFaceToken != "hf_test" {
+		t.Errorf("Expected the configured endpoint and token, got %+v", mlConfig)
+	}
+	if mlConfig.ModelFor("en") != "org/english" {
+		t.Errorf("Expected the English model to be overridden, got %s", mlConfig.ModelFor("en"))
+	}
+	if mlConfig.ModelFor("hi") != "ai4bharat/indic-bert" {
+		t.Errorf("Expected other languages to keep the default model, got %s", mlConfig.ModelFor("hi"))
+	}
+	if http := mlConfig.HTTP; http.Timeout != 3*time.Second || http.MaxRetries != 1 || http.RetryBackoff != 500*time.Millisecond {
+		t.Errorf("Expected configured timeout and retries with the default backoff, got %+v", http)
+	}
+	if http := mlConfig.HTTP; http.CompanyRate != 30 || http.CompanyBurst != 10 || http.BreakerThreshold != 0 {
+		t.Errorf("Expected the configured rate with the default burst and no breaker, got %+v", http)
+	}
+
+	service, err := NewSentimentService(cfg)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := service.Registry().Providers(); len(got) != 3 {
+		t.Errorf("Expected the model server to be registered when ML_API_URL is set, got %v", got)
+	}
+
+	if _, err := NewSentimentService(&config.Config{SentimentProviders: "model_server"}); err == nil {
+		t.Error("Expected error for the model server without ML_API_URL")
+	}
+}
@@ -1,46 +1,134 @@
 package services
 
 import (
-	"strings"
+	"log"
 
+	"github.com/VinVorteX/NoBurn/internal/models"
 	"github.com/VinVorteX/NoBurn/internal/repository"
 	"github.com/VinVorteX/NoBurn/internal/services/sentiment"
 )
 
 type SurveyService struct {
-	surveyRepo *repository.SurveyRepository
-	mlService  *sentiment.MLService
+	surveyRepo  *repository.SurveyRepository
+	companyRepo *repository.CompanyRepository
+	mlService   *sentiment.MLService
 }
 
-func NewSurveyService(hfToken string) *SurveyService {
+func NewSurveyService(mlService *sentiment.MLService) *SurveyService {
 	return &SurveyService{
-		surveyRepo: repository.NewSurveyRepository(),
-		mlService:  sentiment.NewMLService(hfToken),
+		surveyRepo:  repository.NewSurveyRepository(),
+		companyRepo: repository.NewCompanyRepository(),
+		mlService:   mlService,
 	}
 }
 
-func (s *SurveyService) ProcessSurveyResponse(responseID uint, language string) error {
-	// Get response from database
-	responses, err := s.surveyRepo.GetResponsesByUserID(responseID)
-	if err != nil || len(responses) == 0 {
-		return err
+// ScoreResponse scores each answer of a saved response with its company's provider
+// chains, stores the per-answer sentiment and burnout signals and updates the
+// response-level score and burnout index. The response's language is detected from
+// its free text, with language, or else the company's, deciding close calls. The
+// returned response has its Survey loaded.
+func (s *SurveyService) ScoreResponse(responseID uint, language string) (*models.SurveyResponse, error) {
+	response, err := s.surveyRepo.GetResponseByID(responseID)
+	if err != nil {
+		return nil, err
 	}
 
-	response := &responses[len(responses)-1] // Get latest response
+	company, err := s.companyRepo.GetByID(response.Survey.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	if language == "" {
+		language = company.Language
+	}
+	if language == "" {
+		language = "en"
+	}
+	response.Language = sentiment.DetectLanguage(response.Survey.Questions.FreeText(response.Answers), language)
 
-	// Combine all response texts
-	combinedText := strings.Join(response.Responses, " ")
+	// Numeric answers are scored directly and each free-text answer through the provider
+	// chain for its own language, which falls back to the response's for short answers
+	chains := sentiment.Chains(company.SentimentProviders)
+	response.SentimentStatus = models.SentimentScored
+	burnout := map[string]*sentiment.BurnoutScores{}
+	answers := response.Survey.Questions.SentimentPerAnswer(response.Answers, func(text string) (float64, float64, string) {
+		result, err := s.mlService.AnalyzeForCompany(company.ID, chains, text, response.Language)
+		if err != nil {
+			log.Printf("⚠️ Sentiment error for response %d: %v", response.ID, err)
+			response.SentimentStatus = models.SentimentFailed
+		}
+		burnout[text] = result.Burnout
+		return result.Score, result.Confidence, result.Provider
+	})
+	response.Sentiment = models.AverageSentiment(answers)
+	response.BurnoutIndex = applyBurnout(response, answers, burnout)
+	aspects := responseAspects(response, answers, company.AspectKeywords)
 
-	// Analyze sentiment using IndicBERT
-	sentimentScore, err := s.mlService.AnalyzeSentiment(combinedText, language)
-	if err != nil {
-		// Fallback to rule-based
-		sentimentScore = sentiment.AnalyzeSentiment(combinedText, language)
+	if err := s.surveyRepo.SaveSentiment(response, answers, aspects); err != nil {
+		return nil, err
 	}
+	response.AnswerSentiments = answers
+	response.Aspects = aspects
+	return response, nil
+}
 
-	// Update response with sentiment score
-	response.Sentiment = sentimentScore
-	
-	// Save updated response
-	return s.surveyRepo.CreateResponse(response)
-}
\ No newline at end of file
+// applyBurnout copies each free-text answer's burnout scores, keyed by its text, onto
+// its sentiment. The response's index takes the strongest score per dimension across
+// its answers, so one exhausted answer isn't averaged away; it is nil when no answer
+// was classified.
+func applyBurnout(response *models.SurveyResponse, answers []models.AnswerSentiment, byText map[string]*sentiment.BurnoutScores) *float64 {
+	var combined *sentiment.BurnoutScores
+	for i := range answers {
+		if answers[i].Source == models.SourceScale {
+			continue
+		}
+		scores := byText[response.Answers[answers[i].QuestionIndex].Text]
+		if scores == nil {
+			continue
+		}
+		answers[i].Exhaustion = scores.Exhaustion
+		answers[i].Stress = scores.Stress
+		answers[i].Cynicism = scores.Cynicism
+		answers[i].Disengagement = scores.Disengagement
+		answers[i].Signals = scores.Signals()
+
+		strongest := *scores
+		if combined != nil {
+			strongest = combined.Max(strongest)
+		}
+		combined = &strongest
+	}
+
+	if combined == nil {
+		return nil
+	}
+	index := combined.Index()
+	return &index
+}
+
+// responseAspects tags each scored free-text answer with the aspects it mentions,
+// read in the answer's own language
+func responseAspects(response *models.SurveyResponse, answers []models.AnswerSentiment, keywords models.AspectKeywords) []models.ResponseAspect {
+	scores := map[int]float64{}
+	for _, answer := range answers {
+		scores[answer.QuestionIndex] = answer.Sentiment
+	}
+
+	result := []models.ResponseAspect{}
+	for i, q := range response.Survey.Questions {
+		score, scored := scores[i]
+		if q.Type != models.QuestionTypeText || !scored || i >= len(response.Answers) {
+			continue
+		}
+		text := response.Answers[i].Text
+		language := sentiment.DetectLanguage(text, response.Language)
+		for _, mention := range sentiment.ExtractAspects(text, language, keywords, score) {
+			result = append(result, models.ResponseAspect{
+				QuestionIndex: i,
+				Aspect:        mention.Aspect,
+				Sentiment:     mention.Sentiment,
+				Mentions:      mention.Mentions,
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/VinVorteX/NoBurn/internal/config"
+	"github.com/VinVorteX/NoBurn/internal/models"
+	"github.com/VinVorteX/NoBurn/internal/services/sentiment"
+)
+
+// MinGroupSize is the smallest number of respondents an aggregate over anonymous data may describe
+func MinGroupSize() int {
+	if config.AppConfig != nil && config.AppConfig.AnonymityMinGroupSize > 0 {
+		return config.AppConfig.AnonymityMinGroupSize
+	}
+	return 5
+}
+
+type QuestionSummary struct {
+	Question     string         `json:"question"`
+	Type         string         `json:"type"`
+	Answered     int            `json:"answered"`
+	Average      *float64       `json:"average,omitempty"` // likert and nps only
+	ChoiceCounts map[string]int `json:"choice_counts,omitempty"`
+	Suppressed   bool           `json:"suppressed,omitempty"`
+}
+
+type SurveySummary struct {
+	SurveyID     uint              `json:"survey_id"`
+	Respondents  int               `json:"respondents"`
+	AvgSentiment *float64          `json:"avg_sentiment,omitempty"`
+	Questions    []QuestionSummary `json:"questions"`
+	Suppressed   bool              `json:"suppressed,omitempty"`
+}
+
+// SummarizeSurvey aggregates responses per question. Any group with fewer than
+// minGroupSize respondents is marked suppressed and carries no figures; pass 0
+// for surveys that are not anonymous.
+func SummarizeSurvey(survey *models.Survey, responses []models.SurveyResponse, minGroupSize int) SurveySummary {
+	summary := SurveySummary{
+		SurveyID:    survey.ID,
+		Respondents: len(responses),
+		Questions:   []QuestionSummary{},
+	}
+
+	if len(responses) < minGroupSize {
+		summary.Suppressed = true
+		return summary
+	}
+
+	if scored := models.ScoredResponses(responses); len(scored) > 0 {
+		total := 0.0
+		for _, resp := range scored {
+			total += resp.Sentiment
+		}
+		avg := total / float64(len(scored))
+		summary.AvgSentiment = &avg
+	}
+
+	for i, q := range survey.Questions {
+		qs := QuestionSummary{Question: q.Text, Type: q.Type}
+		total := 0.0
+		counts := map[string]int{}
+
+		for _, resp := range responses {
+			if i >= len(resp.Answers) || resp.Answers[i].IsEmpty() {
+				continue
+			}
+			answer := resp.Answers[i]
+			qs.Answered++
+			if answer.Value != nil {
+				total += *answer.Value
+			}
+			for _, choice := range answer.Choices {
+				counts[choice]++
+			}
+		}
+
+		if qs.Answered < minGroupSize {
+			qs.Suppressed = true
+			summary.Questions = append(summary.Questions, qs)
+			continue
+		}
+
+		if q.IsNumeric() && qs.Answered > 0 {
+			avg := total / float64(qs.Answered)
+			qs.Average = &avg
+		}
+		if len(counts) > 0 {
+			qs.ChoiceCounts = counts
+		}
+		summary.Questions = append(summary.Questions, qs)
+	}
+
+	return summary
+}
+
+// QuestionSentiment aggregates the per-answer sentiment stored for one question
+type QuestionSentiment struct {
+	QuestionIndex int            `json:"question_index"`
+	Question      string         `json:"question"`
+	Answered      int            `json:"answered"`
+	AvgSentiment  *float64       `json:"avg_sentiment,omitempty"`
+	Labels        map[string]int `json:"labels,omitempty"`  // positive, neutral, negative
+	Signals       map[string]int `json:"signals,omitempty"` // answers tagged with each burnout dimension
+	Suppressed    bool           `json:"suppressed,omitempty"`
+}
+
+// SummarizeQuestionSentiment aggregates the answer sentiments loaded on responses for
+// every question that has any. Like SummarizeSurvey, questions with fewer than
+// minGroupSize scored answers are suppressed.
+func SummarizeQuestionSentiment(questions models.QuestionList, responses []models.SurveyResponse, minGroupSize int) []QuestionSentiment {
+	byQuestion := map[int][]models.AnswerSentiment{}
+	for _, resp := range responses {
+		for _, answer := range resp.AnswerSentiments {
+			byQuestion[answer.QuestionIndex] = append(byQuestion[answer.QuestionIndex], answer)
+		}
+	}
+
+	result := []QuestionSentiment{}
+	for i, q := range questions {
+		answers := byQuestion[i]
+		if len(answers) == 0 {
+			continue
+		}
+
+		qs := QuestionSentiment{QuestionIndex: i, Question: q.Text, Answered: len(answers)}
+		if qs.Answered < minGroupSize {
+			qs.Suppressed = true
+			result = append(result, qs)
+			continue
+		}
+
+		avg := models.AverageSentiment(answers)
+		qs.AvgSentiment = &avg
+		qs.Labels = map[string]int{models.SentimentPositive: 0, models.SentimentNeutral: 0, models.SentimentNegative: 0}
+		for _, answer := range answers {
+			qs.Labels[answer.Label]++
+			for _, signal := range answer.Signals {
+				if qs.Signals == nil {
+					qs.Signals = map[string]int{}
+				}
+				qs.Signals[signal]++
+			}
+		}
+		result = append(result, qs)
+	}
+	return result
+}
+
+// AspectSummary aggregates the aspect mentions found across responses
+type AspectSummary struct {
+	Aspect       string  `json:"aspect"`
+	Name         string  `json:"name"`
+	Mentions     int     `json:"mentions"`
+	Negative     int     `json:"negative"` // mentions from answers negative on the aspect
+	AvgSentiment float64 `json:"avg_sentiment"`
+}
+
+// SummarizeAspects groups aspects by name, most negative mentions first and, among
+// equals, the lower average sentiment first
+func SummarizeAspects(aspects []models.ResponseAspect) []AspectSummary {
+	byAspect := map[string]*AspectSummary{}
+	totals := map[string]float64{}
+	order := []string{}
+	for _, a := range aspects {
+		summary, ok := byAspect[a.Aspect]
+		if !ok {
+			summary = &AspectSummary{Aspect: a.Aspect, Name: sentiment.AspectName(a.Aspect)}
+			byAspect[a.Aspect] = summary
+			order = append(order, a.Aspect)
+		}
+		summary.Mentions += a.Mentions
+		if models.SentimentLabel(a.Sentiment) == models.SentimentNegative {
+			summary.Negative += a.Mentions
+		}
+		totals[a.Aspect] += a.Sentiment * float64(a.Mentions)
+	}
+
+	result := make([]AspectSummary, 0, len(order))
+	for _, aspect := range order {
+		summary := byAspect[aspect]
+		if summary.Mentions > 0 {
+			summary.AvgSentiment = totals[aspect] / float64(summary.Mentions)
+		}
+		result = append(result, *summary)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Negative != result[j].Negative {
+			return result[i].Negative > result[j].Negative
+		}
+		if result[i].AvgSentiment != result[j].AvgSentiment {
+			return result[i].AvgSentiment < result[j].AvgSentiment
+		}
+		return result[i].Aspect < result[j].Aspect
+	})
+	return result
+}
+
+// DashboardAspects summarizes what the responses a dashboard may show say about each
+// aspect. Identified responses count when their respondent is visible. Anonymous
+// responses can't be narrowed to anyone, so an anonymous survey only counts as a
+// whole, for company-wide viewers, once at least minGroupSize people have answered.
+func DashboardAspects(responses []models.SurveyResponse, visible func(userID uint) bool, companyWide bool, minGroupSize int) []AspectSummary {
+	respondents := map[uint]int{}
+	for _, resp := range responses {
+		if resp.UserID == nil {
+			respondents[resp.SurveyID]++
+		}
+	}
+
+	aspects := []models.ResponseAspect{}
+	for _, resp := range responses {
+		if resp.UserID == nil {
+			if !companyWide || respondents[resp.SurveyID] < minGroupSize {
+				continue
+			}
+		} else if !visible(*resp.UserID) {
+			continue
+		}
+		aspects = append(aspects, resp.Aspects...)
+	}
+	return SummarizeAspects(aspects)
+}
+
+// TopRiskFactors names up to limit aspects employees are negative about
+func TopRiskFactors(summaries []AspectSummary, limit int) []string {
+	factors := []string{}
+	for _, summary := range summaries {
+		if len(factors) == limit {
+			break
+		}
+		if summary.Negative > 0 {
+			factors = append(factors, summary.Name)
+		}
+	}
+	return factors
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/VinVorteX/NoBurn/internal/models"
+)
+
+func TestSummarizeSurvey(t *testing.T) {
+	survey := &models.Survey{
+		ID: 1,
+		Questions: models.QuestionList{
+			{Text: "Rate", Type: models.QuestionTypeLikert, Required: true},
+			{Text: "Pick", Type: models.QuestionTypeSingleChoice, Options: []string{"a", "b"}},
+		},
+	}
+
+	value := func(v float64) *float64 { return &v }
+	responses := []models.SurveyResponse{
+		{Sentiment: 0.5, Answers: models.AnswerList{{Value: value(4)}, {Choices: []string{"a"}}}},
+		{Sentiment: -0.5, Answers: models.AnswerList{{Value: value(2)}, {}}},
+		{Sentiment: 0.3, Answers: models.AnswerList{{Value: value(3)}, {Choices: []string{"b"}}}},
+	}
+
+	summary := SummarizeSurvey(survey, responses, 0)
+	if summary.Suppressed || summary.Respondents != 3 {
+		t.Fatalf("Unexpected summary %+v", summary)
+	}
+	if avg := *summary.Questions[0].Average; avg != 3 {
+		t.Errorf("Expected likert average 3, got %f", avg)
+	}
+	if summary.Questions[1].Answered != 2 || summary.Questions[1].ChoiceCounts["a"] != 1 {
+		t.Errorf("Unexpected choice summary %+v", summary.Questions[1])
+	}
+}
+
+func TestSummarizeSurveySuppressesSmallGroups(t *testing.T) {
+	survey := &models.Survey{
+		Questions: models.QuestionList{
+			{Text: "Rate", Type: models.QuestionTypeLikert, Required: true},
+			{Text: "Comments", Type: models.QuestionTypeText},
+		},
+	}
+
+	value := 4.0
+	responses := []models.SurveyResponse{}
+	for i := 0; i < 3; i++ {
+		responses = append(responses, models.SurveyResponse{Answers: models.AnswerList{{Value: &value}, {}}})
+	}
+
+	summary := SummarizeSurvey(survey, responses, 5)
+	if !summary.Suppressed || summary.AvgSentiment != nil || len(summary.Questions) != 0 {
+		t.Errorf("Expected survey below threshold to be suppressed, got %+v", summary)
+	}
+
+	for i := 0; i < 2; i++ {
+		responses = append(responses, models.SurveyResponse{Answers: models.AnswerList{{Value: &value}, {Text: "ok"}}})
+	}
+
+	summary = SummarizeSurvey(survey, responses, 5)
+	if summary.Suppressed || summary.Questions[0].Average == nil {
+		t.Errorf("Expected survey at threshold to be reported, got %+v", summary)
+	}
+	if !summary.Questions[1].Suppressed {
+		t.Error("Expected optional question with 2 answers to be suppressed")
+	}
+}
+
+func TestSummarizeQuestionSentiment(t *testing.T) {
+	questions := models.QuestionList{
+		{Text: "How do you feel?", Type: models.QuestionTypeText},
+		{Text: "Rate", Type: models.QuestionTypeLikert},
+		{Text: "Anything else?", Type: models.QuestionTypeText},
+	}
+	responses := []models.SurveyResponse{
+		{AnswerSentiments: []models.AnswerSentiment{
+			{QuestionIndex: 0, Sentiment: 0.6, Label: models.SentimentPositive},
+			{QuestionIndex: 1, Sentiment: -0.5, Label: models.SentimentNegative},
+		}},
+		{AnswerSentiments: []models.AnswerSentiment{
+			{QuestionIndex: 0, Sentiment: -0.2, Label: models.SentimentNegative, Signals: models.StringArray{"exhaustion", "stress"}},
+		}},
+	}
+
+	result := SummarizeQuestionSentiment(questions, responses, 2)
+	if len(result) != 2 {
+		t.Fatalf("Expected only questions with scored answers, got %+v", result)
+	}
+	first := result[0]
+	if first.Suppressed || first.Answered != 2 || first.AvgSentiment == nil {
+		t.Fatalf("Unexpected first question %+v", first)
+	}
+	if avg := *first.AvgSentiment; avg < 0.199 || avg > 0.201 {
+		t.Errorf("Expected average 0.2, got %f", avg)
+	}
+	if first.Labels[models.SentimentPositive] != 1 || first.Labels[models.SentimentNegative] != 1 {
+		t.Errorf("Expected one positive and one negative answer, got %v", first.Labels)
+	}
+	if len(first.Signals) != 2 || first.Signals["exhaustion"] != 1 || first.Signals["stress"] != 1 {
+		t.Errorf("Expected one exhausted and stressed answer, got %v", first.Signals)
+	}
+	if second := result[1]; !second.Suppressed || second.AvgSentiment != nil || second.Labels != nil {
+		t.Errorf("Expected the single-answer question to be suppressed, got %+v", second)
+	}
+}
+
+func TestSummarizeAspects(t *testing.T) {
+	aspects := []models.ResponseAspect{
+		{ResponseID: 1, Aspect: "compensation", Sentiment: -0.6, Mentions: 1},
+		{ResponseID: 2, Aspect: "compensation", Sentiment: 0.4, Mentions: 1},
+		{ResponseID: 1, Aspect: "manager", Sentiment: -0.8, Mentions: 1},
+		{ResponseID: 2, Aspect: "manager", Sentiment: -0.2, Mentions: 2},
+		{ResponseID: 3, Aspect: "workload", Sentiment: -0.5, Mentions: 1},
+		{ResponseID: 3, Aspect: "culture", Sentiment: 0.7, Mentions: 1},
+	}
+
+	summaries := SummarizeAspects(aspects)
+	if len(summaries) != 4 {
+		t.Fatalf("Expected 4 aspects, got %+v", summaries)
+	}
+	manager := summaries[0]
+	if manager.Aspect != "manager" || manager.Name != "Manager" || manager.Mentions != 3 || manager.Negative != 3 {
+		t.Errorf("Expected manager first with 3 negative mentions, got %+v", manager)
+	}
+	if manager.AvgSentiment > -0.39 || manager.AvgSentiment < -0.41 {
+		t.Errorf("Expected manager average -0.4, got %f", manager.AvgSentiment)
+	}
+	// compensation and workload both have one negative mention; workload averages lower
+	if summaries[1].Aspect != "workload" || summaries[2].Aspect != "compensation" {
+		t.Errorf("Expected workload before compensation, got %+v", summaries)
+	}
+
+	factors := TopRiskFactors(summaries, 3)
+	want := []string{"Manager", "Workload", "Compensation"}
+	if len(factors) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, factors)
+	}
+	for i := range want {
+		if factors[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, factors)
+		}
+	}
+
+	if factors := TopRiskFactors(SummarizeAspects(aspects[5:]), 3); len(factors) != 0 {
+		t.Errorf("Expected no risk factors from positive mentions, got %v", factors)
+	}
+}
+
+func TestDashboardAspects(t *testing.T) {
+	alice, bob := uint(1), uint(2)
+	negative := func(aspect string) []models.ResponseAspect {
+		return []models.ResponseAspect{{Aspect: aspect, Sentiment: -0.5, Mentions: 1}}
+	}
+	responses := []models.SurveyResponse{
+		{SurveyID: 1, UserID: &alice, Aspects: negative("manager")},
+		{SurveyID: 1, UserID: &bob, Aspects: negative("growth")},
+		{SurveyID: 2, Aspects: negative("workload")},
+		{SurveyID: 2, Aspects: negative("workload")},
+		{SurveyID: 3, Aspects: negative("compensation")},
+	}
+	onlyAlice := func(userID uint) bool { return userID == alice }
+
+	tests := []struct {
+		name        string
+		companyWide bool
+		want        []string
+	}{
+		{"company-wide viewer sees anonymous surveys large enough", true, []string{"manager", "workload"}},
+		{"team viewer only sees identified responses", false, []string{"manager"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summaries := DashboardAspects(responses, onlyAlice, tt.companyWide, 2)
+			got := map[string]bool{}
+			for _, summary := range summaries {
+				got[summary.Aspect] = true
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %+v", tt.want, summaries)
+			}
+			for _, aspect := range tt.want {
+				if !got[aspect] {
+					t.Errorf("Expected %s, got %+v", aspect, summaries)
+				}
+			}
+		})
+	}
+}
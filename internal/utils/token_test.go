@@ -0,0 +1,55 @@
+package utils
+
+import "testing"
+
+func TestGenerateOpaqueToken(t *testing.T) {
+	token, err := GenerateOpaqueToken()
+	if err != nil {
+		t.Fatalf("GenerateOpaqueToken failed: %v", err)
+	}
+
+	if len(token) < 40 {
+		t.Errorf("Expected token of at least 40 chars, got %d", len(token))
+	}
+
+	other, _ := GenerateOpaqueToken()
+	if token == other {
+		t.Error("Expected two generated tokens to differ")
+	}
+}
+
+func TestHashToken(t *testing.T) {
+	hash := HashToken("abc")
+
+	if hash != HashToken("abc") {
+		t.Error("Expected hashing to be deterministic")
+	}
+
+	if hash == "abc" || len(hash) != 64 {
+		t.Errorf("Unexpected hash %s", hash)
+	}
+
+	if hash == HashToken("abd") {
+		t.Error("Expected different tokens to hash differently")
+	}
+}
+
+func TestParticipationMarker(t *testing.T) {
+	marker := ParticipationMarker("secret", "salt", 7)
+
+	if marker != ParticipationMarker("secret", "salt", 7) {
+		t.Error("Expected marker to be deterministic")
+	}
+
+	if marker == ParticipationMarker("secret", "other-salt", 7) {
+		t.Error("Expected marker to change with the survey salt")
+	}
+
+	if marker == ParticipationMarker("other-secret", "salt", 7) {
+		t.Error("Expected marker to change with the server secret")
+	}
+
+	if marker == ParticipationMarker("secret", "salt", 8) {
+		t.Error("Expected different users to get different markers")
+	}
+}
@@ -1,9 +1,12 @@
 package worker
 
 import (
+	"fmt"
+	"log"
 	"time"
 
 	"github.com/hibiken/asynq"
+	"github.com/VinVorteX/NoBurn/internal/models"
 )
 
 type Client struct {
@@ -15,13 +18,16 @@ func NewClient(redisAddr string) *Client {
 	return &Client{client: client}
 }
 
+// EnqueueSurveyProcessing queues scoring for a response. The task ID is derived from
+// the response, so a response that is already queued is not queued twice and
+// asynq.ErrTaskIDConflict is returned instead.
 func (c *Client) EnqueueSurveyProcessing(responseID, userID uint, language string) error {
 	task, err := NewProcessSurveyTask(responseID, userID, language)
 	if err != nil {
 		return err
 	}
 
-	_, err = c.client.Enqueue(task, asynq.Queue("default"))
+	_, err = c.client.Enqueue(task, asynq.Queue("default"), asynq.TaskID(fmt.Sprintf("survey:process:%d", responseID)))
 	return err
 }
 
@@ -55,6 +61,23 @@ func (c *Client) EnqueueSurveyInvitation(surveyID, userID uint, email string) er
 	return err
 }
 
+// EnqueueSurveyInvitations invites every employee (admins are skipped) and returns how many were enqueued
+func (c *Client) EnqueueSurveyInvitations(surveyID uint, employees []models.User) int {
+	sentCount := 0
+	for _, employee := range employees {
+		if employee.Role == models.RoleHRAdmin {
+			continue
+		}
+		log.Printf("📧 Sending survey invitation to %s", employee.Email)
+		if err := c.EnqueueSurveyInvitation(surveyID, employee.ID, employee.Email); err != nil {
+			log.Printf("❌ Failed to enqueue survey invitation for %s: %v", employee.Email, err)
+		} else {
+			sentCount++
+		}
+	}
+	return sentCount
+}
+
 func (c *Client) ScheduleDailyChurnAnalysis(companyID uint) error {
 	// Schedule daily churn analysis for all users in company
 	task, err := NewCalculateChurnTask(0, companyID) // 0 means all users
@@ -69,6 +92,16 @@ func (c *Client) ScheduleDailyChurnAnalysis(companyID uint) error {
 	return err
 }
 
+func (c *Client) EnqueueChurnModelTraining(companyID uint) error {
+	task, err := NewTrainChurnModelTask(companyID)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.Enqueue(task, asynq.Queue("low"))
+	return err
+}
+
 func (c *Client) Close() error {
 	return c.client.Close()
 }
\ No newline at end of file
@@ -3,28 +3,41 @@ package worker
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/hibiken/asynq"
+	"github.com/VinVorteX/NoBurn/internal/config"
+	"github.com/VinVorteX/NoBurn/internal/models"
 	"github.com/VinVorteX/NoBurn/internal/repository"
 	"github.com/VinVorteX/NoBurn/internal/services"
+	"github.com/VinVorteX/NoBurn/internal/services/sentiment"
+	"github.com/VinVorteX/NoBurn/internal/utils"
 	"github.com/VinVorteX/NoBurn/pkg/logger"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type TaskHandler struct {
 	analyticsService *services.AnalyticsService
+	surveyService    *services.SurveyService
 	userRepo         *repository.UserRepository
 	surveyRepo       *repository.SurveyRepository
+	client           *Client
 }
 
-func NewTaskHandler(analyticsService *services.AnalyticsService, userRepo *repository.UserRepository, surveyRepo *repository.SurveyRepository) *TaskHandler {
+func NewTaskHandler(analyticsService *services.AnalyticsService, surveyService *services.SurveyService, userRepo *repository.UserRepository, surveyRepo *repository.SurveyRepository, client *Client) *TaskHandler {
 	return &TaskHandler{
 		analyticsService: analyticsService,
+		surveyService:    surveyService,
 		userRepo:         userRepo,
 		surveyRepo:       surveyRepo,
+		client:           client,
 	}
 }
 
@@ -40,21 +53,23 @@ func (h *TaskHandler) HandleProcessSurvey(ctx context.Context, t *asynq.Task) er
 		zap.String("language", payload.Language),
 	)
 
-	// Process sentiment analysis in background
-	// TODO: Get actual response from database and process
-	
-	// Trigger churn calculation after processing
-	churnTask, err := NewCalculateChurnTask(payload.UserID, 1) // TODO: Get actual company ID
+	response, err := h.surveyService.ScoreResponse(payload.ResponseID, payload.Language)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Log.Warn("Survey response not found", zap.Uint("response_id", payload.ResponseID))
+		return fmt.Errorf("survey response %d not found: %w", payload.ResponseID, asynq.SkipRetry)
+	}
 	if err != nil {
-		logger.Log.Error("Failed to create churn task", zap.Error(err))
+		logger.Log.Error("Failed to score survey response", zap.Error(err))
 		return err
 	}
 
-	client := asynq.NewClient(asynq.RedisClientOpt{Addr: "localhost:6379"})
-	defer client.Close()
+	// Anonymous answers can't be attributed to anyone's churn risk
+	if response.UserID == nil {
+		return nil
+	}
 
-	_, err = client.Enqueue(churnTask)
-	if err != nil {
+	// Trigger churn calculation now that the new sentiment is stored
+	if err := h.client.EnqueueChurnCalculation(*response.UserID, response.Survey.CompanyID); err != nil {
 		logger.Log.Error("Failed to enqueue churn task", zap.Error(err))
 	}
 
@@ -89,32 +104,64 @@ func (h *TaskHandler) HandleCalculateChurn(ctx context.Context, t *asynq.Task) e
 	}
 	log.Printf("✅ WORKER: Risk saved to database")
 
-	// If high risk, send notification
-	if risk.RiskScore > 0.7 {
-		log.Printf("🚨 WORKER: High risk detected (%.2f), sending notification", risk.RiskScore)
-		notificationTask, err := NewSendNotificationTask(
-			payload.UserID,
-			"high_churn_risk",
-			fmt.Sprintf("Employee has high churn risk: %.2f", risk.RiskScore),
-		)
-		if err != nil {
-			return err
-		}
+	if err := h.evaluateAlerts(risk); err != nil {
+		// The risk is saved; a retry would recalculate it, so only log
+		log.Printf("❌ WORKER: Failed to evaluate alert rules for user %d: %v", payload.UserID, err)
+	}
+
+	log.Printf("✅ WORKER: Churn calculation completed successfully")
+	return nil
+}
 
-		client := asynq.NewClient(asynq.RedisClientOpt{Addr: "localhost:6379"})
-		defer client.Close()
+// evaluateAlerts checks the company's alert rules against a new risk and sends one
+// notification listing every rule that fired
+func (h *TaskHandler) evaluateAlerts(risk *models.AttritionRisk) error {
+	user, err := h.userRepo.GetByID(risk.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %v", err)
+	}
 
-		_, err = client.Enqueue(notificationTask)
-		if err != nil {
-			log.Printf("❌ WORKER: Failed to enqueue notification: %v", err)
-		} else {
-			log.Printf("✅ WORKER: Notification task enqueued")
+	rules, err := repository.NewAlertRuleRepository().GetEffective(user.CompanyID)
+	if err != nil {
+		return fmt.Errorf("failed to get alert rules: %v", err)
+	}
+
+	now := time.Now()
+	since := now.Add(-services.AlertLookback(rules))
+	history, err := repository.NewAttritionRepository().GetHistory(user.ID, since)
+	if err != nil {
+		return fmt.Errorf("failed to get risk history: %v", err)
+	}
+	responses, err := h.surveyRepo.GetResponsesByUserID(user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get responses: %v", err)
+	}
+
+	alertRepo := repository.NewAlertRepository()
+	recent, err := alertRepo.GetByUserID(user.ID, since)
+	if err != nil {
+		return fmt.Errorf("failed to get recent alerts: %v", err)
+	}
+
+	alerts := services.EvaluateAlertRules(rules, services.AlertInput{Risk: *risk, History: history, Responses: responses}, recent, now)
+	if len(alerts) == 0 {
+		log.Printf("ℹ️ WORKER: Risk score %.2f matched no alert rules, no notification sent", risk.RiskScore)
+		return nil
+	}
+
+	messages := []string{}
+	for i := range alerts {
+		if err := alertRepo.Create(&alerts[i]); err != nil {
+			return fmt.Errorf("failed to save alert: %v", err)
 		}
-	} else {
-		log.Printf("ℹ️ WORKER: Risk score %.2f below threshold, no notification sent", risk.RiskScore)
+		messages = append(messages, alerts[i].Message)
 	}
 
-	log.Printf("✅ WORKER: Churn calculation completed successfully")
+	log.Printf("🚨 WORKER: %d alert rule(s) fired for user %d, sending notification", len(alerts), user.ID)
+	if err := h.client.EnqueueNotification(user.ID, "churn_alert", strings.Join(messages, "; ")); err != nil {
+		return fmt.Errorf("failed to enqueue notification: %v", err)
+	}
+	log.Printf("✅ WORKER: Notification task enqueued")
 	return nil
 }
 
@@ -136,27 +183,39 @@ func (h *TaskHandler) HandleSendNotification(ctx context.Context, t *asynq.Task)
 		return fmt.Errorf("failed to get user: %v", err)
 	}
 
-	// Initialize notification service with config from env
-	notifService := services.NewNotificationService(
-		"", // Slack webhook from env
-		"smtp.gmail.com",
-		587,
-		"", // SMTP user from env
-		"", // SMTP password from env
-	)
+	notifService := notificationServiceFor(&user.Company, config.AppConfig.SlackWebhookURL)
 	log.Printf("📧 Sending notifications for user: %s", user.Email)
 
+	// Give the alert the employee's place in the org chart
+	message := payload.Message
+	if user.DepartmentID != nil {
+		if department, err := repository.NewDepartmentRepository().GetByID(*user.DepartmentID); err == nil {
+			message = fmt.Sprintf("%s (%s)", message, department.Name)
+		}
+	}
+
 	// Send Slack alert
 	log.Printf("🔔 Attempting Slack alert...")
-	if err := notifService.SendSlackAlert(user.Name, payload.Message, 0.85); err != nil {
+	if err := notifService.SendSlackAlert(user.Name, message, 0.85); err != nil {
 		log.Printf("❌ Slack alert failed: %v", err)
 	} else {
 		log.Printf("✅ Slack alert sent successfully")
 	}
 
-	// Send Email alert to configured alert email
-	log.Printf("📧 Attempting email alert...")
-	if err := notifService.SendEmailAlert("", user.Name, payload.Message, 0.85); err != nil {
+	// Email the employee's manager, falling back to the configured alert address
+	alertEmail := config.AppConfig.AlertEmail
+	if user.ManagerID != nil {
+		if manager, err := h.userRepo.GetByID(*user.ManagerID); err == nil {
+			alertEmail = manager.Email
+		} else {
+			log.Printf("❌ WORKER: Failed to get manager %d: %v", *user.ManagerID, err)
+		}
+	}
+
+	log.Printf("📧 Attempting email alert to %s...", alertEmail)
+	if alertEmail == "" {
+		log.Printf("ℹ️ No manager or alert email configured, skipping email alert")
+	} else if err := notifService.SendEmailAlert(alertEmail, user.Name, message, 0.85); err != nil {
 		log.Printf("❌ Email alert FAILED: %v", err)
 	} else {
 		log.Printf("✅ Email alert sent successfully")
@@ -189,22 +248,25 @@ func (h *TaskHandler) HandleSurveyInvitation(ctx context.Context, t *asynq.Task)
 		return fmt.Errorf("failed to get company: %v", err)
 	}
 
-	// Use company SMTP or fallback to env
-	smtpHost := company.SMTPHost
-	smtpPort := company.SMTPPort
-	smtpUser := company.SMTPUser
-	smtpPassword := company.SMTPPassword
+	// Issue a single-use invitation token scoped to this survey and user
+	rawToken, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate survey token: %v", err)
+	}
 
-	if smtpUser == "" {
-		// Fallback to env variables
-		smtpHost = os.Getenv("SMTP_HOST")
-		smtpPort = 587
-		smtpUser = os.Getenv("SMTP_USER")
-		smtpPassword = os.Getenv("SMTP_PASSWORD")
+	tokenRepo := repository.NewSurveyTokenRepository()
+	if err := tokenRepo.Create(&models.SurveyToken{
+		SurveyID:  payload.SurveyID,
+		UserID:    payload.UserID,
+		TokenHash: utils.HashToken(rawToken),
+		ExpiresAt: time.Now().Add(config.AppConfig.SurveyTokenTTL),
+	}); err != nil {
+		log.Printf("❌ WORKER: Failed to save survey token: %v", err)
+		return fmt.Errorf("failed to save survey token: %v", err)
 	}
 
 	// Generate survey link with token
-	surveyLink := fmt.Sprintf("http://localhost:3002/survey/%d?token=%d", payload.SurveyID, payload.UserID)
+	surveyLink := fmt.Sprintf("http://localhost:3002/survey/%d?token=%s", payload.SurveyID, url.QueryEscape(rawToken))
 
 	// Create email content
 	emailSubject := fmt.Sprintf("New Survey: %s", survey.Title)
@@ -222,14 +284,8 @@ Thank you!
 NoBurn HR Team
 `, survey.Title, surveyLink)
 
-	// Initialize notification service
-	notifService := services.NewNotificationService(
-		"", // No Slack for survey invitations
-		smtpHost,
-		smtpPort,
-		smtpUser,
-		smtpPassword,
-	)
+	// No Slack for survey invitations
+	notifService := notificationServiceFor(company, "")
 
 	// Send email invitation
 	log.Printf("📧 WORKER: Sending survey email to %s", payload.Email)
@@ -238,6 +294,156 @@ NoBurn HR Team
 		return fmt.Errorf("failed to send survey invitation: %v", err)
 	}
 
+	if err := repository.NewUserActivityRepository().RecordInvitation(payload.UserID); err != nil {
+		log.Printf("❌ WORKER: Failed to record invitation for user %d: %v", payload.UserID, err)
+	}
+
 	log.Printf("✅ WORKER: Survey invitation sent successfully to %s", payload.Email)
 	return nil
-}
\ No newline at end of file
+}
+
+func (h *TaskHandler) HandleRunSurveySchedule(ctx context.Context, t *asynq.Task) error {
+	var payload SurveySchedulePayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v", err)
+	}
+
+	scheduleRepo := repository.NewSurveyScheduleRepository()
+	schedule, err := scheduleRepo.GetByID(payload.ScheduleID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Printf("ℹ️ WORKER: Schedule %d no longer exists, skipping", payload.ScheduleID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get schedule: %v", err)
+	}
+
+	company, err := repository.NewCompanyRepository().GetByID(schedule.CompanyID)
+	if err != nil {
+		return fmt.Errorf("failed to get company: %v", err)
+	}
+
+	now := time.Now()
+	if !schedule.IsActive || !schedule.Due(now, company.Timezone) {
+		log.Printf("ℹ️ WORKER: Schedule %d not due, skipping", schedule.ID)
+		return nil
+	}
+
+	// Load everything that can fail before opening the pulse, so a retry starts clean
+	employees, err := h.userRepo.GetByCompanyID(schedule.CompanyID)
+	if err != nil {
+		return fmt.Errorf("failed to get employees: %v", err)
+	}
+
+	template := schedule.Survey
+	survey := &models.Survey{
+		CompanyID:   schedule.CompanyID,
+		Title:       fmt.Sprintf("%s (%s)", template.Title, now.Format("2006-01-02")),
+		Questions:   template.Questions,
+		IsActive:    true,
+		IsAnonymous: template.IsAnonymous,
+	}
+	if survey.IsAnonymous {
+		// Fresh salt per instance so markers can't be matched across pulses
+		if survey.AnonymitySalt, err = utils.GenerateOpaqueToken(); err != nil {
+			return fmt.Errorf("failed to generate anonymity salt: %v", err)
+		}
+	}
+
+	// Closing the previous pulse, opening the next and recording the run commit together
+	if err := scheduleRepo.OpenInstance(schedule, survey, now); err != nil {
+		return fmt.Errorf("failed to open scheduled survey: %v", err)
+	}
+
+	sentCount := h.client.EnqueueSurveyInvitations(survey.ID, employees)
+	log.Printf("✅ WORKER: Scheduled survey %d opened, invitations enqueued for %d employees", survey.ID, sentCount)
+	return nil
+}
+func (h *TaskHandler) HandleTrainChurnModel(ctx context.Context, t *asynq.Task) error {
+	var payload TrainChurnModelPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v", err)
+	}
+
+	companyIDs := []uint{payload.CompanyID}
+	if payload.CompanyID == 0 {
+		companies, err := repository.NewCompanyRepository().GetAll()
+		if err != nil {
+			return fmt.Errorf("failed to get companies: %v", err)
+		}
+		companyIDs = companyIDs[:0]
+		for _, company := range companies {
+			companyIDs = append(companyIDs, company.ID)
+		}
+	}
+
+	trainer := services.NewChurnTrainingService()
+	for _, companyID := range companyIDs {
+		model, err := trainer.Train(companyID)
+		if errors.Is(err, sentiment.ErrInsufficientData) {
+			// Not an error worth retrying; the default weights stay in use until there is more history
+			log.Printf("ℹ️ WORKER: Not enough exit history to train a churn model for company %d", companyID)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to train churn model for company %d: %v", companyID, err)
+		}
+		log.Printf("✅ WORKER: Trained churn model v%d for company %d (%d samples, %.0f%% accuracy)", model.Version, companyID, model.Samples, model.Accuracy*100)
+	}
+	return nil
+}
+
+// pendingGrace is how long a response may wait for its scoring task before it is
+// queued again, and pendingBatch caps how many are queued per run
+const (
+	pendingGrace = 10 * time.Minute
+	pendingBatch = 500
+)
+
+// HandleRequeuePending queues scoring for responses that are still pending, in case
+// the API couldn't reach Redis when they were submitted. Responses whose task is
+// still queued are skipped by the task ID check.
+func (h *TaskHandler) HandleRequeuePending(ctx context.Context, t *asynq.Task) error {
+	responses, err := h.surveyRepo.GetPendingResponses(time.Now().Add(-pendingGrace), pendingBatch)
+	if err != nil {
+		return fmt.Errorf("failed to get pending responses: %v", err)
+	}
+
+	requeued := 0
+	for _, response := range responses {
+		var userID uint
+		if response.UserID != nil {
+			userID = *response.UserID
+		}
+		err := h.client.EnqueueSurveyProcessing(response.ID, userID, "")
+		if errors.Is(err, asynq.ErrTaskIDConflict) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to requeue response %d: %v", response.ID, err)
+		}
+		requeued++
+	}
+	if requeued > 0 {
+		log.Printf("🔁 WORKER: Requeued sentiment scoring for %d pending responses", requeued)
+	}
+	return nil
+}
+
+// notificationServiceFor uses the company's SMTP settings, falling back to env
+func notificationServiceFor(company *models.Company, slackWebhookURL string) *services.NotificationService {
+	smtpHost := company.SMTPHost
+	smtpPort := company.SMTPPort
+	smtpUser := company.SMTPUser
+	smtpPassword := company.SMTPPassword
+
+	if smtpUser == "" {
+		// Fallback to env variables
+		smtpHost = os.Getenv("SMTP_HOST")
+		smtpPort = 587
+		smtpUser = os.Getenv("SMTP_USER")
+		smtpPassword = os.Getenv("SMTP_PASSWORD")
+	}
+
+	return services.NewNotificationService(slackWebhookURL, smtpHost, smtpPort, smtpUser, smtpPassword)
+}
@@ -0,0 +1,134 @@
+package worker
+
+import (
+	"log"
+	"time"
+
+	"github.com/VinVorteX/NoBurn/internal/repository"
+	"github.com/VinVorteX/NoBurn/pkg/logger"
+	"github.com/hibiken/asynq"
+	"github.com/robfig/cron/v3"
+)
+
+// SurveyScheduler keeps asynq's periodic tasks in sync with the survey_schedules table.
+// It also retrains every company's churn model weekly and re-queues responses whose
+// scoring task was never enqueued. Every worker process runs one, so each periodic task
+// is enqueued as unique and the copies other replicas enqueue for the same tick are dropped.
+type SurveyScheduler struct {
+	manager *asynq.PeriodicTaskManager
+}
+
+func NewSurveyScheduler(redisAddr string, scheduleRepo *repository.SurveyScheduleRepository, companyRepo *repository.CompanyRepository) (*SurveyScheduler, error) {
+	manager, err := asynq.NewPeriodicTaskManager(asynq.PeriodicTaskManagerOpts{
+		RedisConnOpt: asynq.RedisClientOpt{Addr: redisAddr},
+		PeriodicTaskConfigProvider: &scheduleConfigProvider{
+			scheduleRepo: scheduleRepo,
+			companyRepo:  companyRepo,
+		},
+		SyncInterval: time.Minute,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &SurveyScheduler{manager: manager}, nil
+}
+
+func (s *SurveyScheduler) Start() error {
+	logger.Log.Info("Starting survey scheduler")
+	return s.manager.Start()
+}
+
+func (s *SurveyScheduler) Stop() {
+	logger.Log.Info("Stopping survey scheduler")
+	s.manager.Shutdown()
+}
+
+type scheduleConfigProvider struct {
+	scheduleRepo *repository.SurveyScheduleRepository
+	companyRepo  *repository.CompanyRepository
+}
+
+// churnTrainingSpec retrains churn models early on Sunday mornings
+const churnTrainingSpec = "0 3 * * 0"
+
+// requeuePendingSpec looks for responses still waiting to be scored
+const requeuePendingSpec = "*/10 * * * *"
+
+func (p *scheduleConfigProvider) GetConfigs() ([]*asynq.PeriodicTaskConfig, error) {
+	schedules, err := p.scheduleRepo.GetActive()
+	if err != nil {
+		return nil, err
+	}
+
+	trainTask, err := NewTrainChurnModelTask(0)
+	if err != nil {
+		return nil, err
+	}
+	configs := []*asynq.PeriodicTaskConfig{{
+		Cronspec: churnTrainingSpec,
+		Task:     trainTask,
+		Opts:     []asynq.Option{asynq.Queue("low"), asynq.Unique(uniqueFor(churnTrainingSpec))},
+	}, {
+		Cronspec: requeuePendingSpec,
+		Task:     NewRequeuePendingTask(),
+		Opts:     []asynq.Option{asynq.Queue("low"), asynq.Unique(uniqueFor(requeuePendingSpec))},
+	}}
+	for _, schedule := range schedules {
+		company, err := p.companyRepo.GetByID(schedule.CompanyID)
+		if err != nil {
+			log.Printf("❌ SCHEDULER: Skipping schedule %d, failed to get company timezone: %v", schedule.ID, err)
+			continue
+		}
+
+		spec, err := schedule.Spec(company.Timezone)
+		if err != nil {
+			log.Printf("❌ SCHEDULER: Skipping schedule %d: %v", schedule.ID, err)
+			continue
+		}
+
+		task, err := NewRunSurveyScheduleTask(schedule.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		configs = append(configs, &asynq.PeriodicTaskConfig{
+			Cronspec: spec,
+			Task:     task,
+			Opts:     []asynq.Option{asynq.Queue("default"), asynq.Unique(uniqueFor(spec))},
+		})
+	}
+	return configs, nil
+}
+
+// uniqueFor returns how long a periodic task stays unique once enqueued: half the
+// shortest gap between its ticks, capped at an hour. That is long enough to drop the
+// copies every replica enqueues for one tick and short enough to never block the next.
+// It only depends on spec, since asynq re-registers a task whose options change.
+func uniqueFor(spec string) time.Duration {
+	const maxUnique = time.Hour
+
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return time.Minute
+	}
+
+	// A fixed starting point keeps the result stable between config syncs
+	tick := schedule.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	gap := time.Duration(0)
+	for i := 0; i < 16; i++ {
+		next := schedule.Next(tick)
+		if gap == 0 || next.Sub(tick) < gap {
+			gap = next.Sub(tick)
+		}
+		tick = next
+	}
+
+	unique := gap / 2
+	if unique > maxUnique {
+		unique = maxUnique
+	}
+	if unique < time.Second {
+		unique = time.Second
+	}
+	return unique
+}
@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUniqueFor(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want time.Duration
+	}{
+		{"every ten minutes", requeuePendingSpec, 5 * time.Minute},
+		{"weekly", churnTrainingSpec, time.Hour},
+		{"weekdays in a timezone", "CRON_TZ=Asia/Kolkata 30 9 * * 1-5", time.Hour},
+		{"every minute", "* * * * *", 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := uniqueFor(tt.spec); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
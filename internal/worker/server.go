@@ -14,7 +14,7 @@ type WorkerServer struct {
 	handler *TaskHandler
 }
 
-func NewWorkerServer(redisAddr string, analyticsService *services.AnalyticsService, userRepo *repository.UserRepository, surveyRepo *repository.SurveyRepository) *WorkerServer {
+func NewWorkerServer(redisAddr string, analyticsService *services.AnalyticsService, surveyService *services.SurveyService, userRepo *repository.UserRepository, surveyRepo *repository.SurveyRepository) *WorkerServer {
 	server := asynq.NewServer(
 		asynq.RedisClientOpt{Addr: redisAddr},
 		asynq.Config{
@@ -28,13 +28,16 @@ func NewWorkerServer(redisAddr string, analyticsService *services.AnalyticsServi
 	)
 
 	mux := asynq.NewServeMux()
-	handler := NewTaskHandler(analyticsService, userRepo, surveyRepo)
+	handler := NewTaskHandler(analyticsService, surveyService, userRepo, surveyRepo, NewClient(redisAddr))
 
 	// Register task handlers
 	mux.HandleFunc(TypeProcessSurvey, handler.HandleProcessSurvey)
 	mux.HandleFunc(TypeCalculateChurn, handler.HandleCalculateChurn)
 	mux.HandleFunc(TypeSendNotification, handler.HandleSendNotification)
 	mux.HandleFunc(TypeSurveyInvitation, handler.HandleSurveyInvitation)
+	mux.HandleFunc(TypeRunSurveySchedule, handler.HandleRunSurveySchedule)
+	mux.HandleFunc(TypeTrainChurnModel, handler.HandleTrainChurnModel)
+	mux.HandleFunc(TypeRequeuePending, handler.HandleRequeuePending)
 
 	return &WorkerServer{
 		server:  server,
@@ -51,4 +54,5 @@ func (ws *WorkerServer) Start() error {
 func (ws *WorkerServer) Stop() {
 	logger.Log.Info("Stopping Asynq worker server")
 	ws.server.Shutdown()
+	ws.handler.client.Close()
 }
\ No newline at end of file
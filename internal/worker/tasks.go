@@ -11,6 +11,9 @@ const (
 	TypeCalculateChurn     = "churn:calculate"
 	TypeSendNotification   = "notification:send"
 	TypeSurveyInvitation   = "survey:invitation"
+	TypeRunSurveySchedule  = "survey:schedule"
+	TypeTrainChurnModel    = "churn:train"
+	TypeRequeuePending     = "survey:requeue_pending"
 )
 
 type SurveyPayload struct {
@@ -36,6 +39,14 @@ type SurveyInvitationPayload struct {
 	Email    string `json:"email"`
 }
 
+type SurveySchedulePayload struct {
+	ScheduleID uint `json:"schedule_id"`
+}
+
+type TrainChurnModelPayload struct {
+	CompanyID uint `json:"company_id"` // 0 retrains every company
+}
+
 func NewProcessSurveyTask(responseID, userID uint, language string) (*asynq.Task, error) {
 	payload, err := json.Marshal(SurveyPayload{
 		ResponseID: responseID,
@@ -81,4 +92,27 @@ func NewSurveyInvitationTask(surveyID, userID uint, email string) (*asynq.Task,
 		return nil, err
 	}
 	return asynq.NewTask(TypeSurveyInvitation, payload), nil
-}
\ No newline at end of file
+}
+
+func NewRunSurveyScheduleTask(scheduleID uint) (*asynq.Task, error) {
+	payload, err := json.Marshal(SurveySchedulePayload{
+		ScheduleID: scheduleID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asynq.NewTask(TypeRunSurveySchedule, payload), nil
+}
+
+func NewTrainChurnModelTask(companyID uint) (*asynq.Task, error) {
+	payload, err := json.Marshal(TrainChurnModelPayload{
+		CompanyID: companyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asynq.NewTask(TypeTrainChurnModel, payload), nil
+}
+func NewRequeuePendingTask() *asynq.Task {
+	return asynq.NewTask(TypeRequeuePending, nil)
+}
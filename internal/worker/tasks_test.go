@@ -83,4 +83,50 @@ func TestNewSendNotificationTask(t *testing.T) {
 	if payload.Message != "Test message" {
 		t.Errorf("Expected Message 'Test message', got %s", payload.Message)
 	}
-}
\ No newline at end of file
+}
+func TestNewRunSurveyScheduleTask(t *testing.T) {
+	task, err := NewRunSurveyScheduleTask(3)
+	if err != nil {
+		t.Fatalf("NewRunSurveyScheduleTask failed: %v", err)
+	}
+
+	if task.Type() != TypeRunSurveySchedule {
+		t.Errorf("Expected task type %s, got %s", TypeRunSurveySchedule, task.Type())
+	}
+
+	var payload SurveySchedulePayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		t.Fatalf("Failed to unmarshal payload: %v", err)
+	}
+
+	if payload.ScheduleID != 3 {
+		t.Errorf("Expected ScheduleID 3, got %d", payload.ScheduleID)
+	}
+}
+
+func TestNewTrainChurnModelTask(t *testing.T) {
+	task, err := NewTrainChurnModelTask(3)
+	if err != nil {
+		t.Fatalf("NewTrainChurnModelTask failed: %v", err)
+	}
+
+	if task.Type() != TypeTrainChurnModel {
+		t.Errorf("Expected task type %s, got %s", TypeTrainChurnModel, task.Type())
+	}
+
+	var payload TrainChurnModelPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		t.Fatalf("Failed to unmarshal payload: %v", err)
+	}
+
+	if payload.CompanyID != 3 {
+		t.Errorf("Expected CompanyID 3, got %d", payload.CompanyID)
+	}
+}
+
+func TestNewRequeuePendingTask(t *testing.T) {
+	task := NewRequeuePendingTask()
+	if task.Type() != TypeRequeuePending {
+		t.Errorf("Expected task type %s, got %s", TypeRequeuePending, task.Type())
+	}
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS survey_tokens;
\ No newline at end of file
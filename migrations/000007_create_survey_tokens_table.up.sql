@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS survey_tokens (
+    id SERIAL PRIMARY KEY,
+    survey_id INTEGER REFERENCES surveys(id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_survey_tokens_survey_id ON survey_tokens(survey_id);
+CREATE INDEX idx_survey_tokens_user_id ON survey_tokens(user_id);
@@ -0,0 +1 @@
+ALTER TABLE survey_responses DROP COLUMN IF EXISTS answers;
@@ -0,0 +1 @@
+ALTER TABLE survey_responses ADD COLUMN IF NOT EXISTS answers JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,4 @@
+ALTER TABLE surveys DROP COLUMN IF EXISTS schedule_id;
+ALTER TABLE surveys DROP COLUMN IF EXISTS is_template;
+DROP TABLE IF EXISTS survey_schedules;
+ALTER TABLE companies DROP COLUMN IF EXISTS timezone;
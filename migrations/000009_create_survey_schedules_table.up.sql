@@ -0,0 +1,26 @@
+ALTER TABLE companies ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) DEFAULT 'UTC';
+
+CREATE TABLE IF NOT EXISTS survey_schedules (
+    id SERIAL PRIMARY KEY,
+    company_id INTEGER REFERENCES companies(id) ON DELETE CASCADE,
+    survey_id INTEGER REFERENCES surveys(id) ON DELETE CASCADE,
+    cronspec VARCHAR(100),
+    interval_weeks INTEGER DEFAULT 1,
+    weekday INTEGER DEFAULT 0,
+    hour INTEGER DEFAULT 0,
+    minute INTEGER DEFAULT 0,
+    timezone VARCHAR(64),
+    is_active BOOLEAN DEFAULT true,
+    last_run_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX idx_survey_schedules_company_id ON survey_schedules(company_id);
+CREATE INDEX idx_survey_schedules_deleted_at ON survey_schedules(deleted_at);
+
+ALTER TABLE surveys ADD COLUMN IF NOT EXISTS is_template BOOLEAN DEFAULT false;
+ALTER TABLE surveys ADD COLUMN IF NOT EXISTS schedule_id INTEGER REFERENCES survey_schedules(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_surveys_schedule_id ON surveys(schedule_id);
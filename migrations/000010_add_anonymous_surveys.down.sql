@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS survey_participations;
+ALTER TABLE surveys DROP COLUMN IF EXISTS anonymity_salt;
+ALTER TABLE surveys DROP COLUMN IF EXISTS is_anonymous;
@@ -0,0 +1,11 @@
+ALTER TABLE surveys ADD COLUMN IF NOT EXISTS is_anonymous BOOLEAN DEFAULT false;
+ALTER TABLE surveys ADD COLUMN IF NOT EXISTS anonymity_salt VARCHAR(64);
+
+CREATE TABLE IF NOT EXISTS survey_participations (
+    id SERIAL PRIMARY KEY,
+    survey_id INTEGER REFERENCES surveys(id) ON DELETE CASCADE,
+    marker VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_survey_participations_marker ON survey_participations(survey_id, marker);
@@ -0,0 +1,15 @@
+DROP POLICY IF EXISTS tenant_isolation ON attrition_risks;
+ALTER TABLE attrition_risks NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE attrition_risks DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON survey_responses;
+ALTER TABLE survey_responses NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE survey_responses DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON surveys;
+ALTER TABLE surveys NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE surveys DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON users;
+ALTER TABLE users NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE users DISABLE ROW LEVEL SECURITY;
@@ -0,0 +1,40 @@
+-- Row-level security as a second tenant guard. Requests run their queries in a
+-- transaction with app.company_id set to the caller's company; background jobs
+-- that work across companies leave it unset.
+-- FORCE applies the policies to the table owner, which the application connects as.
+
+ALTER TABLE users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE users FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON users
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR company_id = current_setting('app.company_id', true)::integer
+    );
+
+ALTER TABLE surveys ENABLE ROW LEVEL SECURITY;
+ALTER TABLE surveys FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON surveys
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR company_id = current_setting('app.company_id', true)::integer
+    );
+
+ALTER TABLE survey_responses ENABLE ROW LEVEL SECURITY;
+ALTER TABLE survey_responses FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON survey_responses
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR survey_id IN (
+            SELECT id FROM surveys WHERE company_id = current_setting('app.company_id', true)::integer
+        )
+    );
+
+ALTER TABLE attrition_risks ENABLE ROW LEVEL SECURITY;
+ALTER TABLE attrition_risks FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON attrition_risks
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR user_id IN (
+            SELECT id FROM users WHERE company_id = current_setting('app.company_id', true)::integer
+        )
+    );
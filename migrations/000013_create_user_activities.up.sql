@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS user_activities (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    last_login_at TIMESTAMP,
+    last_response_at TIMESTAMP,
+    invitations_sent INTEGER NOT NULL DEFAULT 0,
+    invitations_answered INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
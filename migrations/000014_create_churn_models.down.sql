@@ -0,0 +1,3 @@
+ALTER TABLE attrition_risks DROP COLUMN IF EXISTS model_version;
+ALTER TABLE attrition_risks DROP COLUMN IF EXISTS features;
+DROP TABLE IF EXISTS churn_models;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS churn_models (
+    id SERIAL PRIMARY KEY,
+    company_id INTEGER REFERENCES companies(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    features JSONB,
+    weights JSONB,
+    bias DOUBLE PRECISION DEFAULT 0,
+    samples INTEGER DEFAULT 0,
+    leavers INTEGER DEFAULT 0,
+    accuracy DOUBLE PRECISION DEFAULT 0,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (company_id, version)
+);
+
+CREATE INDEX idx_churn_models_company_id ON churn_models(company_id);
+
+ALTER TABLE attrition_risks ADD COLUMN IF NOT EXISTS features JSONB;
+ALTER TABLE attrition_risks ADD COLUMN IF NOT EXISTS model_version INTEGER;
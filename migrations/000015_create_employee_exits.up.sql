@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS employee_exits (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    company_id INTEGER REFERENCES companies(id) ON DELETE CASCADE,
+    manager_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    exit_date TIMESTAMP NOT NULL,
+    exit_type VARCHAR(20) NOT NULL CHECK (exit_type IN ('voluntary', 'involuntary')),
+    reason TEXT,
+    regretted BOOLEAN DEFAULT false,
+    recorded_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_employee_exits_company_id ON employee_exits(company_id);
+
+ALTER TABLE employee_exits ENABLE ROW LEVEL SECURITY;
+ALTER TABLE employee_exits FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON employee_exits
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR company_id = current_setting('app.company_id', true)::integer
+    );
@@ -0,0 +1 @@
+ALTER TABLE attrition_risks DROP COLUMN IF EXISTS contributions;
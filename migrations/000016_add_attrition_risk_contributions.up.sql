@@ -0,0 +1 @@
+ALTER TABLE attrition_risks ADD COLUMN IF NOT EXISTS contributions JSONB;
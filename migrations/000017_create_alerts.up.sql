@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS alert_rules (
+    id SERIAL PRIMARY KEY,
+    company_id INTEGER REFERENCES companies(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(50) NOT NULL CHECK (type IN ('threshold', 'risk_increase', 'negative_streak')),
+    threshold DOUBLE PRECISION DEFAULT 0,
+    window_days INTEGER DEFAULT 0,
+    count INTEGER DEFAULT 0,
+    cooldown_days INTEGER DEFAULT 14,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_alert_rules_company_id ON alert_rules(company_id);
+
+CREATE TABLE IF NOT EXISTS alerts (
+    id SERIAL PRIMARY KEY,
+    company_id INTEGER REFERENCES companies(id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    rule_id INTEGER REFERENCES alert_rules(id) ON DELETE SET NULL,
+    rule_type VARCHAR(50) NOT NULL,
+    rule_name VARCHAR(255),
+    risk_score DOUBLE PRECISION DEFAULT 0,
+    message TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_alerts_company_id ON alerts(company_id);
+CREATE INDEX idx_alerts_user_id ON alerts(user_id, created_at);
+
+ALTER TABLE alert_rules ENABLE ROW LEVEL SECURITY;
+ALTER TABLE alert_rules FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON alert_rules
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR company_id = current_setting('app.company_id', true)::integer
+    );
+
+ALTER TABLE alerts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE alerts FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON alerts
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR company_id = current_setting('app.company_id', true)::integer
+    );
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS risk_settings (
+    id SERIAL PRIMARY KEY,
+    company_id INTEGER REFERENCES companies(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    high_risk_threshold DOUBLE PRECISION NOT NULL,
+    watch_threshold DOUBLE PRECISION NOT NULL,
+    sentiment_weight DOUBLE PRECISION NOT NULL,
+    response_weight DOUBLE PRECISION NOT NULL,
+    activity_weight DOUBLE PRECISION NOT NULL,
+    engagement_weight DOUBLE PRECISION NOT NULL,
+    low_sentiment_cutoff DOUBLE PRECISION NOT NULL,
+    low_response_rate_cutoff DOUBLE PRECISION NOT NULL,
+    inactive_days_cutoff INTEGER NOT NULL,
+    negative_ratio_cutoff DOUBLE PRECISION NOT NULL,
+    infrequent_logins_cutoff INTEGER NOT NULL,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (company_id, version)
+);
+
+ALTER TABLE risk_settings ENABLE ROW LEVEL SECURITY;
+ALTER TABLE risk_settings FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON risk_settings
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR company_id = current_setting('app.company_id', true)::integer
+    );
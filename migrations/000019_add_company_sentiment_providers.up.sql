@@ -0,0 +1 @@
+ALTER TABLE companies ADD COLUMN IF NOT EXISTS sentiment_providers JSONB;
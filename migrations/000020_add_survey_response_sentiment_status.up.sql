@@ -0,0 +1 @@
+ALTER TABLE survey_responses ADD COLUMN IF NOT EXISTS sentiment_status VARCHAR(20) NOT NULL DEFAULT 'scored';
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS answer_sentiments (
+    id SERIAL PRIMARY KEY,
+    response_id INTEGER NOT NULL REFERENCES survey_responses(id) ON DELETE CASCADE,
+    survey_id INTEGER NOT NULL REFERENCES surveys(id) ON DELETE CASCADE,
+    question_index INTEGER NOT NULL,
+    sentiment DOUBLE PRECISION NOT NULL DEFAULT 0,
+    label VARCHAR(20) NOT NULL CHECK (label IN ('positive', 'neutral', 'negative')),
+    confidence DOUBLE PRECISION NOT NULL DEFAULT 0,
+    source VARCHAR(50),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_answer_sentiments_answer ON answer_sentiments(response_id, question_index);
+CREATE INDEX idx_answer_sentiments_survey_id ON answer_sentiments(survey_id);
+
+ALTER TABLE answer_sentiments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE answer_sentiments FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON answer_sentiments
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR survey_id IN (
+            SELECT id FROM surveys WHERE company_id = current_setting('app.company_id', true)::integer
+        )
+    );
@@ -0,0 +1 @@
+ALTER TABLE survey_responses ADD COLUMN IF NOT EXISTS language VARCHAR(20);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS response_aspects;
+ALTER TABLE companies DROP COLUMN IF EXISTS aspect_keywords;
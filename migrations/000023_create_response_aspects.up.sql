@@ -0,0 +1,25 @@
+ALTER TABLE companies ADD COLUMN IF NOT EXISTS aspect_keywords JSONB;
+
+CREATE TABLE IF NOT EXISTS response_aspects (
+    id SERIAL PRIMARY KEY,
+    response_id INTEGER NOT NULL REFERENCES survey_responses(id) ON DELETE CASCADE,
+    survey_id INTEGER NOT NULL REFERENCES surveys(id) ON DELETE CASCADE,
+    question_index INTEGER NOT NULL,
+    aspect VARCHAR(50) NOT NULL,
+    sentiment DOUBLE PRECISION NOT NULL DEFAULT 0,
+    mentions INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_response_aspects_answer ON response_aspects(response_id, question_index, aspect);
+CREATE INDEX idx_response_aspects_survey_id ON response_aspects(survey_id);
+
+ALTER TABLE response_aspects ENABLE ROW LEVEL SECURITY;
+ALTER TABLE response_aspects FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON response_aspects
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR survey_id IN (
+            SELECT id FROM surveys WHERE company_id = current_setting('app.company_id', true)::integer
+        )
+    );
@@ -0,0 +1,10 @@
+ALTER TABLE risk_settings DROP COLUMN IF EXISTS high_burnout_cutoff;
+ALTER TABLE risk_settings DROP COLUMN IF EXISTS burnout_weight;
+
+ALTER TABLE survey_responses DROP COLUMN IF EXISTS burnout_index;
+
+ALTER TABLE answer_sentiments DROP COLUMN IF EXISTS signals;
+ALTER TABLE answer_sentiments DROP COLUMN IF EXISTS disengagement;
+ALTER TABLE answer_sentiments DROP COLUMN IF EXISTS cynicism;
+ALTER TABLE answer_sentiments DROP COLUMN IF EXISTS stress;
+ALTER TABLE answer_sentiments DROP COLUMN IF EXISTS exhaustion;
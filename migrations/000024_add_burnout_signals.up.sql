@@ -0,0 +1,11 @@
+ALTER TABLE answer_sentiments ADD COLUMN IF NOT EXISTS exhaustion DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE answer_sentiments ADD COLUMN IF NOT EXISTS stress DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE answer_sentiments ADD COLUMN IF NOT EXISTS cynicism DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE answer_sentiments ADD COLUMN IF NOT EXISTS disengagement DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE answer_sentiments ADD COLUMN IF NOT EXISTS signals JSONB;
+
+ALTER TABLE survey_responses ADD COLUMN IF NOT EXISTS burnout_index DOUBLE PRECISION;
+
+-- Saved settings keep their behaviour: burnout has no weight until a company gives it one
+ALTER TABLE risk_settings ADD COLUMN IF NOT EXISTS burnout_weight DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE risk_settings ADD COLUMN IF NOT EXISTS high_burnout_cutoff DOUBLE PRECISION NOT NULL DEFAULT 0.5;
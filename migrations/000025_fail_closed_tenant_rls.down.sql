@@ -0,0 +1,130 @@
+DROP POLICY IF EXISTS tenant_isolation ON churn_models;
+ALTER TABLE churn_models DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON user_activities;
+ALTER TABLE user_activities DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON survey_schedules;
+ALTER TABLE survey_schedules DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON survey_participations;
+ALTER TABLE survey_participations DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON survey_tokens;
+ALTER TABLE survey_tokens DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON teams;
+ALTER TABLE teams DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON departments;
+ALTER TABLE departments DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON companies;
+ALTER TABLE companies DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON response_aspects;
+ALTER TABLE response_aspects FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON response_aspects
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR survey_id IN (
+            SELECT id FROM surveys WHERE company_id = current_setting('app.company_id', true)::integer
+        )
+    );
+
+DROP POLICY IF EXISTS tenant_isolation ON answer_sentiments;
+ALTER TABLE answer_sentiments FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON answer_sentiments
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR survey_id IN (
+            SELECT id FROM surveys WHERE company_id = current_setting('app.company_id', true)::integer
+        )
+    );
+
+DROP POLICY IF EXISTS tenant_isolation ON risk_settings;
+ALTER TABLE risk_settings FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON risk_settings
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR company_id = current_setting('app.company_id', true)::integer
+    );
+
+DROP POLICY IF EXISTS tenant_isolation ON alerts;
+ALTER TABLE alerts FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON alerts
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR company_id = current_setting('app.company_id', true)::integer
+    );
+
+DROP POLICY IF EXISTS tenant_isolation ON alert_rules;
+ALTER TABLE alert_rules FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON alert_rules
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR company_id = current_setting('app.company_id', true)::integer
+    );
+
+DROP POLICY IF EXISTS tenant_isolation ON employee_exits;
+ALTER TABLE employee_exits FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON employee_exits
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR company_id = current_setting('app.company_id', true)::integer
+    );
+
+DROP POLICY IF EXISTS tenant_isolation ON attrition_risks;
+ALTER TABLE attrition_risks FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON attrition_risks
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR user_id IN (
+            SELECT id FROM users WHERE company_id = current_setting('app.company_id', true)::integer
+        )
+    );
+
+DROP POLICY IF EXISTS tenant_isolation ON survey_responses;
+ALTER TABLE survey_responses FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON survey_responses
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR survey_id IN (
+            SELECT id FROM surveys WHERE company_id = current_setting('app.company_id', true)::integer
+        )
+    );
+
+DROP POLICY IF EXISTS tenant_isolation ON surveys;
+ALTER TABLE surveys FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON surveys
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR company_id = current_setting('app.company_id', true)::integer
+    );
+
+DROP POLICY IF EXISTS tenant_isolation ON users;
+ALTER TABLE users FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON users
+    USING (
+        NULLIF(current_setting('app.company_id', true), '') IS NULL
+        OR company_id = current_setting('app.company_id', true)::integer
+    );
+
+-- The role is shared by every database in the cluster, so only this database's
+-- grants are revoked. The role itself is dropped once nothing else depends on it.
+ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE USAGE, SELECT ON SEQUENCES FROM noburn_tenant;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE SELECT, INSERT, UPDATE, DELETE ON TABLES FROM noburn_tenant;
+REVOKE ALL ON ALL SEQUENCES IN SCHEMA public FROM noburn_tenant;
+REVOKE ALL ON ALL TABLES IN SCHEMA public FROM noburn_tenant;
+REVOKE USAGE ON SCHEMA public FROM noburn_tenant;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_shdepend d JOIN pg_roles r ON r.oid = d.refobjid
+        WHERE d.refclassid = 'pg_authid'::regclass AND r.rolname = 'noburn_tenant'
+    ) THEN
+        DROP ROLE IF EXISTS noburn_tenant;
+    END IF;
+END
+$$;
@@ -0,0 +1,132 @@
+-- Makes the tenant policies fail closed and moves background jobs to an explicit opt-out.
+-- Requests run their queries in a transaction that switches to the noburn_tenant role
+-- and sets app.company_id to the caller's company. The policies only match that
+-- company, so a missing or empty setting returns no rows instead of every company's.
+-- RLS is no longer forced, so the table owner the application connects as bypasses
+-- it. That connection is what background jobs working across companies use; a role
+-- with BYPASSRLS works the same way. Tenant-owned tables without policies get them.
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'noburn_tenant') THEN
+        CREATE ROLE noburn_tenant NOLOGIN;
+    END IF;
+END
+$$;
+
+GRANT noburn_tenant TO CURRENT_USER;
+GRANT USAGE ON SCHEMA public TO noburn_tenant;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO noburn_tenant;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO noburn_tenant;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO noburn_tenant;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT USAGE, SELECT ON SEQUENCES TO noburn_tenant;
+
+DROP POLICY IF EXISTS tenant_isolation ON users;
+ALTER TABLE users NO FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON users
+    USING (company_id = NULLIF(current_setting('app.company_id', true), '')::integer);
+
+DROP POLICY IF EXISTS tenant_isolation ON surveys;
+ALTER TABLE surveys NO FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON surveys
+    USING (company_id = NULLIF(current_setting('app.company_id', true), '')::integer);
+
+DROP POLICY IF EXISTS tenant_isolation ON survey_responses;
+ALTER TABLE survey_responses NO FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON survey_responses
+    USING (
+        survey_id IN (
+            SELECT id FROM surveys WHERE company_id = NULLIF(current_setting('app.company_id', true), '')::integer
+        )
+    );
+
+DROP POLICY IF EXISTS tenant_isolation ON attrition_risks;
+ALTER TABLE attrition_risks NO FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON attrition_risks
+    USING (
+        user_id IN (
+            SELECT id FROM users WHERE company_id = NULLIF(current_setting('app.company_id', true), '')::integer
+        )
+    );
+
+DROP POLICY IF EXISTS tenant_isolation ON employee_exits;
+ALTER TABLE employee_exits NO FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON employee_exits
+    USING (company_id = NULLIF(current_setting('app.company_id', true), '')::integer);
+
+DROP POLICY IF EXISTS tenant_isolation ON alert_rules;
+ALTER TABLE alert_rules NO FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON alert_rules
+    USING (company_id = NULLIF(current_setting('app.company_id', true), '')::integer);
+
+DROP POLICY IF EXISTS tenant_isolation ON alerts;
+ALTER TABLE alerts NO FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON alerts
+    USING (company_id = NULLIF(current_setting('app.company_id', true), '')::integer);
+
+DROP POLICY IF EXISTS tenant_isolation ON risk_settings;
+ALTER TABLE risk_settings NO FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON risk_settings
+    USING (company_id = NULLIF(current_setting('app.company_id', true), '')::integer);
+
+DROP POLICY IF EXISTS tenant_isolation ON answer_sentiments;
+ALTER TABLE answer_sentiments NO FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON answer_sentiments
+    USING (
+        survey_id IN (
+            SELECT id FROM surveys WHERE company_id = NULLIF(current_setting('app.company_id', true), '')::integer
+        )
+    );
+
+DROP POLICY IF EXISTS tenant_isolation ON response_aspects;
+ALTER TABLE response_aspects NO FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON response_aspects
+    USING (
+        survey_id IN (
+            SELECT id FROM surveys WHERE company_id = NULLIF(current_setting('app.company_id', true), '')::integer
+        )
+    );
+
+ALTER TABLE companies ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON companies
+    USING (id = NULLIF(current_setting('app.company_id', true), '')::integer);
+
+ALTER TABLE departments ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON departments
+    USING (company_id = NULLIF(current_setting('app.company_id', true), '')::integer);
+
+ALTER TABLE teams ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON teams
+    USING (company_id = NULLIF(current_setting('app.company_id', true), '')::integer);
+
+ALTER TABLE survey_tokens ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON survey_tokens
+    USING (
+        survey_id IN (
+            SELECT id FROM surveys WHERE company_id = NULLIF(current_setting('app.company_id', true), '')::integer
+        )
+    );
+
+ALTER TABLE survey_participations ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON survey_participations
+    USING (
+        survey_id IN (
+            SELECT id FROM surveys WHERE company_id = NULLIF(current_setting('app.company_id', true), '')::integer
+        )
+    );
+
+ALTER TABLE survey_schedules ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON survey_schedules
+    USING (company_id = NULLIF(current_setting('app.company_id', true), '')::integer);
+
+ALTER TABLE user_activities ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON user_activities
+    USING (
+        user_id IN (
+            SELECT id FROM users WHERE company_id = NULLIF(current_setting('app.company_id', true), '')::integer
+        )
+    );
+
+ALTER TABLE churn_models ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON churn_models
+    USING (company_id = NULLIF(current_setting('app.company_id', true), '')::integer);